@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"resume_maker/backend/internal/handlers"
+	"resume_maker/backend/internal/pdfgen"
 )
 
 func main() {
@@ -14,6 +15,13 @@ func main() {
 		port = "8080"
 	}
 
+	if templateDir := os.Getenv("PDF_TEMPLATE_DIR"); templateDir != "" {
+		if err := pdfgen.LoadTemplateDir(templateDir); err != nil {
+			slog.Error("load pdf templates", "dir", templateDir, "error", err.Error())
+			os.Exit(1)
+		}
+	}
+
 	handler := handlers.NewRouter("1.0.0")
 	slog.Info("starting server", "port", port)
 	if err := http.ListenAndServe(":"+port, handler); err != nil {
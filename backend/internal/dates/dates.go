@@ -0,0 +1,147 @@
+// Package dates parses the free-form start and end dates entered on resume entries and
+// formats them in a consistent style. It accepts month names ("Jan 2022", "January 2022",
+// "Aug. 2018"), numeric months ("2022-01", "01/2022"), bare years, and words meaning the
+// present such as "Present" or "current".
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"resume_maker/backend/internal/models"
+)
+
+// Date is a resume date known to the month or only to the year, or the open end of a
+// range that is still ongoing.
+type Date struct {
+	Year int
+	// Month is 1-12, or 0 when only the year is known.
+	Month   int
+	Present bool
+}
+
+// ParseError reports a date in none of the accepted forms.
+type ParseError struct {
+	Value string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("unrecognized date %q", e.Value)
+}
+
+var (
+	yearPattern      = regexp.MustCompile(`^(\d{4})$`)
+	yearMonthPattern = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})$`)
+	monthYearPattern = regexp.MustCompile(`^(\d{1,2})[-/.](\d{4})$`)
+	namedPattern     = regexp.MustCompile(`^([a-z]+)\.?,?\s+(\d{4})$`)
+)
+
+var presentWords = map[string]bool{"present": true, "current": true, "now": true, "ongoing": true, "today": true}
+
+var monthNames = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+
+// Parse reads value in any accepted form. Surrounding space and letter case are ignored.
+func Parse(value string) (Date, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	if presentWords[normalized] {
+		return Date{Present: true}, nil
+	}
+
+	var year, month int
+	if match := yearPattern.FindStringSubmatch(normalized); match != nil {
+		year, _ = strconv.Atoi(match[1])
+	} else if match := yearMonthPattern.FindStringSubmatch(normalized); match != nil {
+		year, _ = strconv.Atoi(match[1])
+		month, _ = strconv.Atoi(match[2])
+	} else if match := monthYearPattern.FindStringSubmatch(normalized); match != nil {
+		month, _ = strconv.Atoi(match[1])
+		year, _ = strconv.Atoi(match[2])
+	} else if match := namedPattern.FindStringSubmatch(normalized); match != nil {
+		month = monthByName(match[1])
+		year, _ = strconv.Atoi(match[2])
+		if month == 0 {
+			return Date{}, &ParseError{Value: value}
+		}
+	} else {
+		return Date{}, &ParseError{Value: value}
+	}
+
+	if month < 0 || month > 12 || (month == 0 && !yearPattern.MatchString(normalized)) {
+		return Date{}, &ParseError{Value: value}
+	}
+	return Date{Year: year, Month: month}, nil
+}
+
+// monthByName returns the month for a full English month name or an abbreviation of at
+// least three letters, such as "jan" or "sept", or 0 if name is not a month.
+func monthByName(name string) int {
+	if len(name) < 3 {
+		return 0
+	}
+	for index, month := range monthNames {
+		if strings.HasPrefix(strings.ToLower(month), name) {
+			return index + 1
+		}
+	}
+	return 0
+}
+
+// Before reports whether d is strictly earlier than other. The present is after every
+// calendar date, and a year-only date is never before a month of the same year.
+func (d Date) Before(other Date) bool {
+	switch {
+	case d.Present:
+		return false
+	case other.Present:
+		return true
+	case d.Year != other.Year:
+		return d.Year < other.Year
+	case d.Month == 0 || other.Month == 0:
+		return false
+	default:
+		return d.Month < other.Month
+	}
+}
+
+// Span returns the first and last month d may refer to, counted as year*12 + month - 1.
+// A year-only date spans January to December, and the present is the month of now.
+func (d Date) Span(now time.Time) (first, last int) {
+	switch {
+	case d.Present:
+		month := now.Year()*12 + int(now.Month()) - 1
+		return month, month
+	case d.Month == 0:
+		return d.Year * 12, d.Year*12 + 11
+	default:
+		month := d.Year*12 + d.Month - 1
+		return month, month
+	}
+}
+
+// Format writes d in style, one of models.DateFormats. Year-only dates print as the year
+// in every style, and unknown styles fall back to models.DateFormatShort.
+func (d Date) Format(style string) string {
+	if d.Present {
+		if style == models.DateFormatNumeric {
+			return "Now"
+		}
+		return "Present"
+	}
+	if d.Month == 0 || style == models.DateFormatYear {
+		return strconv.Itoa(d.Year)
+	}
+
+	switch style {
+	case models.DateFormatLong:
+		return fmt.Sprintf("%s %d", monthNames[d.Month-1], d.Year)
+	case models.DateFormatNumeric:
+		return fmt.Sprintf("%02d/%d", d.Month, d.Year)
+	case models.DateFormatISO:
+		return fmt.Sprintf("%d-%02d", d.Year, d.Month)
+	default:
+		return fmt.Sprintf("%s %d", monthNames[d.Month-1][:3], d.Year)
+	}
+}
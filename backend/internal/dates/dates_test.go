@@ -0,0 +1,104 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]Date{
+		"Jan 2022":     {Year: 2022, Month: 1},
+		"january 2022": {Year: 2022, Month: 1},
+		"Aug. 2018":    {Year: 2018, Month: 8},
+		"Sept 2019":    {Year: 2019, Month: 9},
+		"Dec, 2020":    {Year: 2020, Month: 12},
+		"2022-01":      {Year: 2022, Month: 1},
+		"2022/3":       {Year: 2022, Month: 3},
+		"01/2022":      {Year: 2022, Month: 1},
+		"7-2021":       {Year: 2021, Month: 7},
+		" 2022 ":       {Year: 2022},
+		"Present":      {Present: true},
+		"current":      {Present: true},
+		"NOW":          {Present: true},
+		"  Ongoing   ": {Present: true},
+	}
+	for value, want := range tests {
+		got, err := Parse(value)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", value, err)
+		}
+		if got != want {
+			t.Fatalf("Parse(%q) = %+v, want %+v", value, got, want)
+		}
+	}
+}
+
+func TestParseRejectsUnknownForms(t *testing.T) {
+	for _, value := range []string{"", "Summer 2022", "13/2022", "2022-00", "Ja 2022", "22", "next year", "Jan 22"} {
+		_, err := Parse(value)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Parse(%q): expected a ParseError, got %v", value, err)
+		}
+	}
+}
+
+func TestBefore(t *testing.T) {
+	tests := []struct {
+		a, b Date
+		want bool
+	}{
+		{Date{Year: 2021, Month: 12}, Date{Year: 2022, Month: 1}, true},
+		{Date{Year: 2022, Month: 3}, Date{Year: 2022, Month: 1}, false},
+		{Date{Year: 2022}, Date{Year: 2022, Month: 6}, false},
+		{Date{Year: 2022, Month: 6}, Date{Year: 2022}, false},
+		{Date{Year: 2030}, Date{Present: true}, true},
+		{Date{Present: true}, Date{Year: 2030}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Before(tt.b); got != tt.want {
+			t.Fatalf("%+v.Before(%+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSpan(t *testing.T) {
+	now := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		date        Date
+		first, last int
+	}{
+		{Date{Year: 2022, Month: 3}, 2022*12 + 2, 2022*12 + 2},
+		{Date{Year: 2022}, 2022 * 12, 2022*12 + 11},
+		{Date{Present: true}, 2024*12 + 4, 2024*12 + 4},
+	}
+	for _, tt := range tests {
+		first, last := tt.date.Span(now)
+		if first != tt.first || last != tt.last {
+			t.Fatalf("%+v.Span() = (%d, %d), want (%d, %d)", tt.date, first, last, tt.first, tt.last)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	month := Date{Year: 2022, Month: 1}
+	year := Date{Year: 2019}
+	present := Date{Present: true}
+
+	tests := map[string][3]string{
+		models.DateFormatShort:   {"Jan 2022", "2019", "Present"},
+		models.DateFormatLong:    {"January 2022", "2019", "Present"},
+		models.DateFormatNumeric: {"01/2022", "2019", "Now"},
+		models.DateFormatISO:     {"2022-01", "2019", "Present"},
+		models.DateFormatYear:    {"2022", "2019", "Present"},
+	}
+	for style, want := range tests {
+		got := [3]string{month.Format(style), year.Format(style), present.Format(style)}
+		if got != want {
+			t.Fatalf("Format(%q) = %v, want %v", style, got, want)
+		}
+	}
+}
@@ -24,6 +24,13 @@ import (
 	"resume_maker/backend/internal/service"
 )
 
+type templateSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsDefault   bool   `json:"isDefault"`
+}
+
 type errorResponse struct {
 	Error apiError `json:"error"`
 }
@@ -34,6 +41,17 @@ type apiError struct {
 	Details []models.ValidationErrorDetail `json:"details,omitempty"`
 }
 
+// maxHeaderWarnings caps the warnings sent in X-Resume-Warnings so the header stays
+// small; the validate endpoint returns them all.
+const maxHeaderWarnings = 20
+
+// headerWarning is a warning as listed in X-Resume-Warnings. Messages are left out:
+// they quote user text, which header values cannot carry reliably, and may be long.
+type headerWarning struct {
+	Field string `json:"field"`
+	Code  string `json:"code"`
+}
+
 // NewRouter returns the API router used by the server.
 func NewRouter(version string) http.Handler {
 	r := chi.NewRouter()
@@ -46,11 +64,13 @@ func NewRouter(version string) http.Handler {
 		},
 		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
 		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type"},
+		ExposedHeaders: []string{"X-Resume-Pages", "X-Resume-Fit", "X-Resume-Warnings", "X-Resume-Warning-Count"},
 		MaxAge:         300,
 	}))
 	r.Use(requestLogger)
 
 	pdfService := service.NewPDFService(pdfgen.Generator{})
+	keywordService := service.NewKeywordService()
 
 	r.Route("/api/v1", func(api chi.Router) {
 		api.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
@@ -61,67 +81,160 @@ func NewRouter(version string) http.Handler {
 		})
 
 		api.Get("/templates", func(w http.ResponseWriter, _ *http.Request) {
+			templates := pdfgen.Templates()
+			summaries := make([]templateSummary, 0, len(templates))
+			for _, template := range templates {
+				summaries = append(summaries, templateSummary{
+					ID:          template.ID,
+					Name:        template.Name,
+					Description: template.Description,
+					IsDefault:   template.ID == pdfgen.DefaultTemplateID,
+				})
+			}
 			writeJSON(w, http.StatusOK, map[string]any{
-				"templates": []map[string]any{
-					{
-						"id":          "classic",
-						"name":        "Classic",
-						"description": "Clean single-column layout with serif typography. ATS-friendly.",
-						"isDefault":   true,
-					},
-				},
+				"templates": summaries,
 			})
 		})
 
 		api.Post("/resumes/generate-pdf", func(w http.ResponseWriter, r *http.Request) {
-			if !strings.Contains(strings.ToLower(r.Header.Get("Content-Type")), "application/json") {
-				writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Content-Type must be application/json", nil)
+			req, ok := decodeResumeRequest(w, r)
+			if !ok {
 				return
 			}
 
-			bodyBytes, err := io.ReadAll(r.Body)
+			result, err := pdfService.GeneratePDF(r.Context(), req)
 			if err != nil {
-				writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Failed to read request body", nil)
+				writeServiceError(w, "generate pdf", err)
+				return
+			}
+
+			filename := buildFilename(req)
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+			w.Header().Set("X-Resume-Pages", strconv.Itoa(result.Pages))
+			if result.Fit != nil {
+				if fitJSON, marshalErr := json.Marshal(result.Fit); marshalErr == nil {
+					w.Header().Set("X-Resume-Fit", string(fitJSON))
+				}
+			}
+			if len(result.Warnings) > 0 {
+				headerWarnings := make([]headerWarning, 0, maxHeaderWarnings)
+				for _, warning := range result.Warnings[:min(len(result.Warnings), maxHeaderWarnings)] {
+					headerWarnings = append(headerWarnings, headerWarning{Field: warning.Field, Code: warning.Code})
+				}
+				if warningsJSON, marshalErr := json.Marshal(headerWarnings); marshalErr == nil {
+					w.Header().Set("X-Resume-Warnings", string(warningsJSON))
+					w.Header().Set("X-Resume-Warning-Count", strconv.Itoa(len(result.Warnings)))
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(result.Content)
+		})
+
+		api.Post("/resumes/layout", func(w http.ResponseWriter, r *http.Request) {
+			req, ok := decodeResumeRequest(w, r)
+			if !ok {
 				return
 			}
 
-			if err := verifyServiceAuth(r, bodyBytes); err != nil {
-				writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", err.Error(), nil)
+			report, err := pdfService.LayoutResume(r.Context(), req)
+			if err != nil {
+				writeServiceError(w, "lay out resume", err)
 				return
 			}
 
-			var req models.GeneratePDFRequest
-			if err := json.Unmarshal(bodyBytes, &req); err != nil {
-				writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed JSON body", nil)
+			writeJSON(w, http.StatusOK, report)
+		})
+
+		api.Post("/resumes/validate", func(w http.ResponseWriter, r *http.Request) {
+			req, ok := decodeResumeRequest(w, r)
+			if !ok {
 				return
 			}
 
-			pdfBytes, err := pdfService.GeneratePDF(r.Context(), req)
+			report, err := pdfService.ValidateResume(r.Context(), req)
 			if err != nil {
-				var validationErr *service.ValidationError
-				switch {
-				case errors.As(err, &validationErr):
-					writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", validationErr.Details)
-				case errors.Is(err, service.ErrPhotoTooLarge):
-					writeError(w, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "Photo exceeds 5MB limit", nil)
-				default:
-					slog.Error("generate pdf", "error", err.Error())
-					writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected server error", nil)
-				}
+				writeServiceError(w, "validate resume", err)
 				return
 			}
 
-			filename := buildFilename(req)
-			w.Header().Set("Content-Type", "application/pdf")
-			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write(pdfBytes)
+			writeJSON(w, http.StatusOK, report)
+		})
+
+		api.Post("/resumes/keyword-match", func(w http.ResponseWriter, r *http.Request) {
+			var req models.KeywordMatchRequest
+			if !decodeJSONRequest(w, r, &req) {
+				return
+			}
+
+			report, err := keywordService.MatchKeywords(r.Context(), req)
+			if err != nil {
+				writeServiceError(w, "match keywords", err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, report)
 		})
 	})
 
 	return r
 }
 
+// decodeResumeRequest reads, authenticates and decodes a resume payload, writing the
+// error response itself when it returns false.
+func decodeResumeRequest(w http.ResponseWriter, r *http.Request) (models.GeneratePDFRequest, bool) {
+	var req models.GeneratePDFRequest
+	ok := decodeJSONRequest(w, r, &req)
+	return req, ok
+}
+
+// decodeJSONRequest reads, authenticates and decodes a JSON payload into target, writing
+// the error response itself when it returns false.
+func decodeJSONRequest(w http.ResponseWriter, r *http.Request, target any) bool {
+	if !strings.Contains(strings.ToLower(r.Header.Get("Content-Type")), "application/json") {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Content-Type must be application/json", nil)
+		return false
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Failed to read request body", nil)
+		return false
+	}
+
+	if err := verifyServiceAuth(r, bodyBytes); err != nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", err.Error(), nil)
+		return false
+	}
+
+	if err := json.Unmarshal(bodyBytes, target); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed JSON body", nil)
+		return false
+	}
+
+	return true
+}
+
+// writeServiceError maps PDF service errors onto API error responses.
+func writeServiceError(w http.ResponseWriter, operation string, err error) {
+	var validationErr *service.ValidationError
+	var fitErr *pdfgen.FitError
+	switch {
+	case errors.As(err, &validationErr):
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", validationErr.Details)
+	case errors.As(err, &fitErr):
+		writeError(w, http.StatusUnprocessableEntity, "FIT_FAILED", "Resume cannot fit the requested page count", []models.ValidationErrorDetail{{
+			Field:   "settings.fitToPages",
+			Message: fmt.Sprintf("content needs %d pages at the tightest layout", fitErr.Pages),
+		}})
+	case errors.Is(err, service.ErrPhotoTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "Photo exceeds 5MB limit", nil)
+	default:
+		slog.Error(operation, "error", err.Error())
+		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected server error", nil)
+	}
+}
+
 func verifyServiceAuth(r *http.Request, body []byte) error {
 	secret := strings.TrimSpace(os.Getenv("GO_PDF_SERVICE_HMAC_SECRET"))
 	if secret == "" {
@@ -182,12 +295,19 @@ func verifyServiceAuth(r *http.Request, body []byte) error {
 	return nil
 }
 
+// buildFilename names the download after the person and, for a tailored variant, its
+// name, as in "Ada_Lovelace_Backend_Resume.pdf".
 func buildFilename(req models.GeneratePDFRequest) string {
 	first := sanitizeFilename(req.Data.PersonalInfo.FirstName)
 	last := sanitizeFilename(req.Data.PersonalInfo.LastName)
 	if first == "" || last == "" {
 		return "Resume.pdf"
 	}
+	if req.Variant != nil {
+		if variant := sanitizeFilename(req.Variant.Name); variant != "" {
+			return fmt.Sprintf("%s_%s_%s_Resume.pdf", first, last, variant)
+		}
+	}
 	return fmt.Sprintf("%s_%s_Resume.pdf", first, last)
 }
 
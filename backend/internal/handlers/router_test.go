@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +17,13 @@ import (
 	"resume_maker/backend/internal/handlers"
 )
 
+const (
+	generatePDFPath  = "/api/v1/resumes/generate-pdf"
+	layoutPath       = "/api/v1/resumes/layout"
+	validatePath     = "/api/v1/resumes/validate"
+	keywordMatchPath = "/api/v1/resumes/keyword-match"
+)
+
 func TestHealthEndpoint(t *testing.T) {
 	router := handlers.NewRouter("1.0.0")
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
@@ -50,6 +58,57 @@ func TestTemplatesEndpoint(t *testing.T) {
 	if body := rr.Body.String(); !strings.Contains(body, `"id":"classic"`) {
 		t.Fatalf("expected classic template, got %s", body)
 	}
+
+	var response struct {
+		Templates []struct {
+			ID        string `json:"id"`
+			IsDefault bool   `json:"isDefault"`
+		} `json:"templates"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode templates response: %v", err)
+	}
+
+	ids := make(map[string]bool)
+	defaults := 0
+	for _, template := range response.Templates {
+		ids[template.ID] = true
+		if template.IsDefault {
+			defaults++
+		}
+	}
+	for _, id := range []string{"classic", "modern", "compact", "sidebar"} {
+		if !ids[id] {
+			t.Fatalf("expected %s template in registry listing, got %s", id, rr.Body.String())
+		}
+	}
+	if defaults != 1 {
+		t.Fatalf("expected exactly one default template, got %d", defaults)
+	}
+}
+
+func TestGeneratePDFWithNamedTemplate(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload["templateId"] = "compact"
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	if !bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF")) {
+		t.Fatalf("expected PDF bytes to start with %%PDF")
+	}
+}
+
+func TestGeneratePDFValidationErrorForUnknownTemplate(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload["templateId"] = "does-not-exist"
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr, `"field":"templateId"`)
 }
 
 func TestGeneratePDFSuccess(t *testing.T) {
@@ -198,6 +257,41 @@ func TestGeneratePDFSuccessWithTechnicalSkillsOnly(t *testing.T) {
 	}
 }
 
+func TestGeneratePDFSuccessWithSkillCategories(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["technicalSkills"] = []map[string]any{
+		{"label": "ML/AI", "skills": []string{"PyTorch", "scikit-learn"}},
+		{"label": "Cloud", "skills": []string{"AWS", "GCP"}},
+		{"label": "Design Tools", "skills": []string{"Figma"}},
+	}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+}
+
+func TestGeneratePDFValidationErrorForInvalidSkillCategories(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["technicalSkills"] = []map[string]any{
+		{"label": "Languages", "skills": []string{"Go"}},
+		{"label": " ", "skills": []string{"Figma"}},
+		{"label": "Cloud", "skills": []string{" "}},
+		{"label": strings.Repeat("Label ", 8), "skills": []string{"Rust"}},
+	}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"data.technicalSkills[1].label"`,
+		`"field":"data.technicalSkills[2].skills"`,
+		`"field":"data.technicalSkills[3].label"`,
+	)
+	expectBodyOmits(t, rr, "data.technicalSkills[0]")
+}
+
 func TestGeneratePDFValidationError(t *testing.T) {
 	router := handlers.NewRouter("1.0.0")
 	payload := map[string]any{
@@ -274,6 +368,335 @@ func TestGeneratePDFValidationErrorWhenPhotoEnabledButMissing(t *testing.T) {
 	}
 }
 
+func TestGeneratePDFSuccessWithSectionOrderAndVisibility(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{{"company": "Analytical Engines Inc.", "role": "Research Assistant"}}
+	payload.data()["projects"] = []map[string]any{{"name": "Difference Engine Notes"}}
+	payload.settings()["sectionOrder"] = []string{"experience", "education"}
+	payload.settings()["sectionVisibility"] = map[string]bool{"projects": false}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+}
+
+func TestGeneratePDFValidationErrorForUnknownSectionKeys(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	delete(payload.data(), "technicalSkills")
+	payload.data()["projects"] = []map[string]any{{"name": "Difference Engine Notes"}}
+	payload.settings()["sectionOrder"] = []string{"experience", "hobbies", "experience"}
+	payload.settings()["sectionVisibility"] = map[string]bool{"awards": true, "projects": false}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"settings.sectionOrder[1]"`,
+		`"field":"settings.sectionOrder[2]"`,
+		`"field":"settings.sectionVisibility.awards"`,
+		`"field":"settings.sectionVisibility"`,
+	)
+}
+
+func TestGeneratePDFSuccessWithCustomSectionsOnly(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	delete(payload.data(), "technicalSkills")
+	payload.data()["customSections"] = []map[string]any{{
+		"title":   "Certifications",
+		"entries": []map[string]any{{"primary": "AWS Certified Developer", "secondary": "Amazon Web Services", "date": "2024"}},
+	}}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+}
+
+func TestGeneratePDFValidationErrorForInvalidCustomSections(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["customSections"] = []map[string]any{
+		{
+			"title": "Awards",
+			"entries": []map[string]any{
+				{"primary": "Best Paper"},
+				{"date": "2023", "bullets": []string{"Orphaned bullet"}},
+			},
+		},
+		{"title": " "},
+	}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"data.customSections[0].entries[1]"`,
+		`"field":"data.customSections[1].title"`,
+		`"field":"data.customSections[1].entries"`,
+	)
+}
+
+func TestGeneratePDFValidationErrorForOverlongSummary(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["headline"] = strings.Repeat("Analyst ", 20)
+	payload.personalInfo()["summary"] = strings.Repeat("Mathematician and writer. ", 30)
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr, `"field":"data.personalInfo.headline"`, `"field":"data.personalInfo.summary"`)
+}
+
+func TestGeneratePDFValidationErrorForMalformedBulletMarkup(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{{
+		"role":    "Analyst",
+		"bullets": []string{"Cut costs by **40%**", "See [the paper](https://example.com"},
+	}}
+	payload.data()["projects"] = []map[string]any{{
+		"name":    "Notes",
+		"bullets": []string{"See [the paper](javascript:alert(1))"},
+	}}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr, `"field":"data.experience[0].bullets[1]"`, `"field":"data.projects[0].bullets[0]"`)
+	expectBodyOmits(t, rr, `"field":"data.experience[0].bullets[0]"`)
+}
+
+func TestGeneratePDFValidationErrorForInvalidNestedBullets(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{{
+		"role": "Analyst",
+		"bullets": []any{
+			"Flat bullet",
+			map[string]any{
+				"text": "Parent",
+				"children": []any{
+					"See [the paper](https://example.com",
+					map[string]any{
+						"text": "Level two",
+						"children": []any{
+							map[string]any{"text": "Level three", "children": []any{"Level four"}},
+						},
+					},
+				},
+			},
+			map[string]any{"text": " ", "children": []any{"Orphaned child"}},
+		},
+	}}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"data.experience[0].bullets[1].children[0]"`,
+		`"field":"data.experience[0].bullets[1].children[1].children[0].children"`,
+		`"field":"data.experience[0].bullets[2].text"`,
+	)
+}
+
+func TestGeneratePDFSuccessForGroupedAndFlatExperience(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{
+		{
+			"company":  "Engines Ltd",
+			"location": "London",
+			"roles": []map[string]any{
+				{"role": "Senior Analyst", "startDate": "1843", "endDate": "1852", "bullets": []string{"Published the notes."}},
+				{"role": "Analyst", "startDate": "1842", "endDate": "1843", "bullets": []string{"Translated the memoir."}},
+			},
+		},
+		{"company": "Babbage & Co", "role": "Correspondent", "bullets": []string{"Kept the letters flowing."}},
+	}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+}
+
+func TestGeneratePDFValidationErrorForInvalidGroupedExperience(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{{
+		"role":    "Analyst",
+		"bullets": []string{"Belongs on a role"},
+		"roles": []map[string]any{
+			{"role": "Senior Analyst", "bullets": []string{"See [the paper](https://example.com"}},
+			{"role": " ", "employmentType": "seasonal"},
+		},
+	}}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"data.experience[0].company"`,
+		`"field":"data.experience[0].role"`,
+		`"field":"data.experience[0].bullets"`,
+		`"field":"data.experience[0].roles[0].bullets[0]"`,
+		`"field":"data.experience[0].roles[1].role"`,
+		`"field":"data.experience[0].roles[1].employmentType"`,
+	)
+}
+
+func TestGeneratePDFValidationErrorForReversedDates(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{{"role": "Engineer", "startDate": "2022-06", "endDate": "Jan 2022"}}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr, `"field":"data.experience[0].endDate","message":"must not be before startDate"`)
+}
+
+func TestGeneratePDFRendersFreeFormDates(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["email"] = "ada@example.com"
+	payload.data()["experience"] = []map[string]any{{"role": "Research Intern", "startDate": "Summer 2021", "endDate": "Fall 2021"}}
+	payload.settings()["dateFormat"] = "short"
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	want := []string{"unparsedDate data.experience[0].startDate", "unparsedDate data.experience[0].endDate"}
+	if got := warningKeys(t, rr.Header().Get("X-Resume-Warnings")); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v, got %v", want, got)
+	}
+}
+
+func TestGeneratePDFValidationErrorForInvalidGPA(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["education"] = []map[string]any{
+		{"institution": "University of London", "degree": "BSc", "gpa": "3.8", "gpaScale": "4.0", "minor": "Music", "honors": "First Class", "relevantCoursework": []string{"Analysis"}},
+		{"institution": "University of London", "degree": "MSc", "gpa": "4.3", "gpaScale": "4.0"},
+		{"institution": "University of London", "degree": "PhD", "gpa": "excellent", "gpaScale": "-10"},
+	}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"data.education[1].gpa","message":"must not exceed gpaScale 4.0"`,
+		`"field":"data.education[2].gpa"`,
+		`"field":"data.education[2].gpaScale"`,
+	)
+	expectBodyOmits(t, rr, "data.education[0]")
+}
+
+func TestGeneratePDFValidationErrorForInvalidEntryURLs(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{
+		{"company": "Analytical Engines Ltd", "url": "engines.example.com", "role": "Programmer"},
+		{"company": "Royal Society", "url": "javascript:alert(1)", "role": "Fellow"},
+	}
+	payload.data()["projects"] = []map[string]any{
+		{"name": "Bernoulli Numbers", "url": "https://ada.example.com/notes", "repoUrl": "github.com/ada/bernoulli"},
+		{"name": "Loom Cards", "url": "ftp://ada.example.com/cards", "repoUrl": "github.com/ada/loom cards"},
+	}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"data.experience[1].url"`,
+		`"field":"data.projects[1].url"`,
+		`"field":"data.projects[1].repoUrl","message":"must not contain spaces"`,
+	)
+	expectBodyOmits(t, rr, "data.experience[0]", "data.projects[0]")
+}
+
+func TestGeneratePDFSuccessForLetterPaperWithExplicitMargins(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.settings()["pageSize"] = "Letter"
+	payload.settings()["margins"] = map[string]any{"top": 15, "right": 12.7, "bottom": 15, "left": 12.7}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	if !bytes.Contains(rr.Body.Bytes(), []byte("/MediaBox [0 0 612.00 792.00]")) {
+		t.Fatal("expected Letter media box in generated PDF")
+	}
+}
+
+func TestGeneratePDFSuccessForPartialMargins(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.settings()["margins"] = map[string]any{"top": 15}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	// Unset sides keep the template margins.
+	expectStatus(t, rr, http.StatusOK)
+}
+
+func TestGeneratePDFValidationErrorForInvalidLayoutSettings(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	tests := map[string]struct {
+		settings map[string]any
+		fields   []string
+	}{
+		"unknown page size and preset": {
+			settings: map[string]any{"pageSize": "Tabloid", "margins": "tiny"},
+			fields:   []string{`"field":"settings.pageSize"`, `"field":"settings.margins"`},
+		},
+		"unknown theme and malformed colors": {
+			settings: map[string]any{"theme": map[string]any{"preset": "neon", "accent": "blue", "link": "#12345"}},
+			fields:   []string{`"field":"settings.theme.preset"`, `"field":"settings.theme.accent"`, `"field":"settings.theme.link"`},
+		},
+		"unknown header layout": {
+			settings: map[string]any{"headerLayout": "diagonal"},
+			fields:   []string{`"field":"settings.headerLayout"`},
+		},
+		"unknown bullet style": {
+			settings: map[string]any{"bulletStyle": "star"},
+			fields:   []string{`"field":"settings.bulletStyle"`},
+		},
+		"unknown date format": {
+			settings: map[string]any{"dateFormat": "roman"},
+			fields:   []string{`"field":"settings.dateFormat"`},
+		},
+		"unknown sort order and gap length": {
+			settings: map[string]any{"sortEntries": "alphabetical", "gapWarningMonths": -1},
+			fields:   []string{`"field":"settings.sortEntries"`, `"field":"settings.gapWarningMonths"`},
+		},
+		"out of range margins": {
+			settings: map[string]any{"margins": map[string]any{"top": 2, "right": 20, "bottom": 80, "left": 20}},
+			fields:   []string{`"field":"settings.margins.top"`, `"field":"settings.margins.bottom"`},
+		},
+		"out of range partial margins": {
+			settings: map[string]any{"margins": map[string]any{"left": 60}},
+			fields:   []string{`"field":"settings.margins.left"`},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			payload := basePayload()
+			for key, value := range tt.settings {
+				payload.settings()[key] = value
+			}
+
+			rr := postJSON(t, router, generatePDFPath, payload)
+
+			expectStatus(t, rr, http.StatusBadRequest)
+			expectBodyContains(t, rr, tt.fields...)
+		})
+	}
+}
+
 func TestGeneratePDFSuccessForLongResumeContent(t *testing.T) {
 	router := handlers.NewRouter("1.0.0")
 	bullets := make([]string, 0, 60)
@@ -344,6 +767,188 @@ func TestGeneratePDFSuccessForLongResumeContent(t *testing.T) {
 	}
 }
 
+func TestGeneratePDFFitToOnePageReportsAdjustments(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+
+	rr := postJSON(t, router, generatePDFPath, fitPayload(44))
+
+	expectStatus(t, rr, http.StatusOK)
+	if got := rr.Header().Get("X-Resume-Pages"); got != "1" {
+		t.Fatalf("expected X-Resume-Pages 1, got %q", got)
+	}
+
+	var report struct {
+		TargetPages int `json:"targetPages"`
+		Pages       int `json:"pages"`
+		Adjustments []struct {
+			Property string `json:"property"`
+		} `json:"adjustments"`
+	}
+	if err := json.Unmarshal([]byte(rr.Header().Get("X-Resume-Fit")), &report); err != nil {
+		t.Fatalf("decode X-Resume-Fit header %q: %v", rr.Header().Get("X-Resume-Fit"), err)
+	}
+	if report.TargetPages != 1 || report.Pages != 1 || len(report.Adjustments) == 0 {
+		t.Fatalf("expected one-page fit report with adjustments, got %+v", report)
+	}
+}
+
+func TestGeneratePDFFitToOnePageFailsForOverflowingContent(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+
+	rr := postJSON(t, router, generatePDFPath, fitPayload(120))
+
+	expectStatus(t, rr, http.StatusUnprocessableEntity)
+	expectBodyContains(t, rr, "FIT_FAILED", `"field":"settings.fitToPages"`)
+}
+
+func TestLayoutEndpointReportsPagesAndPlacements(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+
+	// fitToPages cannot be met for 60 bullets; the dry run reports the same failure as generation.
+	rr := postJSON(t, router, layoutPath, fitPayload(60))
+	expectStatus(t, rr, http.StatusUnprocessableEntity)
+
+	rr = postJSON(t, router, layoutPath, basePayload())
+
+	expectStatus(t, rr, http.StatusOK)
+	if got := rr.Header().Get("Content-Type"); !strings.Contains(got, "application/json") {
+		t.Fatalf("expected JSON response, got %q", got)
+	}
+
+	var report struct {
+		Pages           int     `json:"pages"`
+		RemainingHeight float64 `json:"remainingHeight"`
+		Sections        []struct {
+			Key  string  `json:"key"`
+			Page int     `json:"page"`
+			Y    float64 `json:"y"`
+		} `json:"sections"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode layout response: %v", err)
+	}
+	if report.Pages != 1 || report.RemainingHeight <= 0 {
+		t.Fatalf("expected one page with remaining space, got %+v", report)
+	}
+	if len(report.Sections) != 1 || report.Sections[0].Key != "technicalSkills" || report.Sections[0].Page != 1 {
+		t.Fatalf("expected technicalSkills placement on page 1, got %+v", report.Sections)
+	}
+}
+
+func TestLayoutEndpointSortsEntriesReverseChronologically(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["email"] = "ada@example.com"
+	payload.data()["experience"] = []map[string]any{
+		{"role": "Analyst", "company": "Babbage & Co", "startDate": "Jan 2015", "endDate": "Dec 2017"},
+		{"role": "Engineer", "company": "Difference Ltd", "startDate": "Jan 2020", "endDate": "Present"},
+		{"role": "Consultant", "company": "Engine Works", "startDate": "Jun 2021", "endDate": "Mar 2022"},
+		{"role": "Tutor", "company": "Evening School", "startDate": "Jan 2021", "endDate": "Present", "employmentType": "partTime"},
+	}
+	payload.data()["education"] = []map[string]any{
+		{"institution": "University of London", "degree": "BSc", "startDate": "2011", "endDate": "2014"},
+		{"institution": "Open University", "degree": "MSc", "startDate": "Sep 2099", "endDate": "Jun 2100"},
+	}
+	payload.settings()["sortEntries"] = "reverseChronological"
+
+	rr := postJSON(t, router, layoutPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	var report struct {
+		Sections []struct {
+			Key     string `json:"key"`
+			Entries []struct {
+				Index int `json:"index"`
+			} `json:"entries"`
+		} `json:"sections"`
+		Warnings []struct {
+			Code string `json:"code"`
+		} `json:"warnings"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode layout response: %v", err)
+	}
+
+	order := map[string][]int{}
+	for _, section := range report.Sections {
+		for _, entry := range section.Entries {
+			order[section.Key] = append(order[section.Key], entry.Index)
+		}
+	}
+	// Ongoing roles come first, the later start breaking the tie.
+	if got := fmt.Sprint(order["experience"]); got != "[3 1 2 0]" {
+		t.Fatalf("expected experience order [3 1 2 0], got %s", got)
+	}
+	if got := fmt.Sprint(order["education"]); got != "[1 0]" {
+		t.Fatalf("expected education order [1 0], got %s", got)
+	}
+	if len(report.Warnings) == 0 {
+		t.Fatal("expected the layout report to carry the timeline warnings")
+	}
+}
+
+func TestValidateEndpointReportsWarningsWithoutBlocking(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["firstName"] = "Augusta Ada King-Noel, Countess of Lovelace and"
+	payload.personalInfo()["lastName"] = "Baroness Wentworth of Nettlestead"
+	payload.personalInfo()["linkedin"] = "linkedin.com/in/ada"
+
+	rr := postJSON(t, router, validatePath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	var report struct {
+		Valid    bool              `json:"valid"`
+		Errors   []json.RawMessage `json:"errors"`
+		Warnings json.RawMessage   `json:"warnings"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode validate response: %v", err)
+	}
+	if !report.Valid || report.Errors == nil || len(report.Errors) != 0 {
+		t.Fatalf("expected a valid report with an empty errors array, got %s", rr.Body.String())
+	}
+	// Request warnings come first, then what the layout reports.
+	want := []string{
+		"missingEmail data.personalInfo.email",
+		"linkWithoutScheme data.personalInfo.linkedin",
+		"overflow data.personalInfo",
+	}
+	if got := warningKeys(t, string(report.Warnings)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v, got %v", want, got)
+	}
+
+	rr = postJSON(t, router, generatePDFPath, payload)
+
+	// Warnings do not block generation.
+	expectStatus(t, rr, http.StatusOK)
+	if got := rr.Header().Get("X-Resume-Warning-Count"); got != strconv.Itoa(len(want)) {
+		t.Fatalf("expected X-Resume-Warning-Count %d, got %q", len(want), got)
+	}
+}
+
+func TestValidateEndpointReportsErrors(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["firstName"] = ""
+
+	rr := postJSON(t, router, validatePath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	expectBodyContains(t, rr, `"valid":false`, `"field":"data.personalInfo.firstName"`, `"warnings":[]`)
+}
+
+func TestLayoutEndpointValidationError(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["firstName"] = ""
+
+	rr := postJSON(t, router, layoutPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr, `"field":"data.personalInfo.firstName"`)
+}
+
 func TestCORSPreflightAllowsEditorOrigin(t *testing.T) {
 	router := handlers.NewRouter("1.0.0")
 	req := httptest.NewRequest(http.MethodOptions, "/api/v1/resumes/generate-pdf", nil)
@@ -362,6 +967,41 @@ func TestCORSPreflightAllowsEditorOrigin(t *testing.T) {
 	}
 }
 
+func TestCORSExposesResumeHeaders(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	req := httptest.NewRequest(http.MethodPost, generatePDFPath, bytes.NewReader(mustMarshalPDFPayload(t)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Origin", "http://localhost:3000")
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	expectStatus(t, rr, http.StatusOK)
+	exposed := rr.Header().Get("Access-Control-Expose-Headers")
+	for _, header := range []string{"X-Resume-Warnings", "X-Resume-Warning-Count", "X-Resume-Pages", "X-Resume-Fit"} {
+		if !strings.Contains(exposed, header) {
+			t.Fatalf("expected %s in Access-Control-Expose-Headers, got %q", header, exposed)
+		}
+	}
+}
+
+func TestGeneratePDFWarningsHeaderLeavesOutMessages(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["firstName"] = "Zoë"
+	payload.personalInfo()["lastName"] = "Müller"
+	payload.personalInfo()["website"] = "bücher.example/zoë"
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	header := rr.Header().Get("X-Resume-Warnings")
+	want := `[{"field":"data.personalInfo.email","code":"missingEmail"},{"field":"data.personalInfo.website","code":"linkWithoutScheme"}]`
+	if header != want {
+		t.Fatalf("expected X-Resume-Warnings %s, got %s", want, header)
+	}
+}
+
 func TestGeneratePDFRejectsUnsignedWhenServiceSecretConfigured(t *testing.T) {
 	t.Setenv("GO_PDF_SERVICE_HMAC_SECRET", "test-secret")
 
@@ -439,3 +1079,251 @@ func mustMarshalPDFPayload(t *testing.T) []byte {
 
 	return bodyBytes
 }
+
+// testPayload is a generate-pdf request body that tests modify before posting.
+type testPayload map[string]any
+
+// basePayload returns a minimal valid payload: a name and one line of technical skills.
+func basePayload() testPayload {
+	return testPayload{
+		"data": map[string]any{
+			"personalInfo":    map[string]any{"firstName": "Ada", "lastName": "Lovelace"},
+			"technicalSkills": map[string]any{"languages": "Go"},
+		},
+		"settings": map[string]any{"showPhoto": false, "fontSize": "medium", "fontFamily": "times"},
+	}
+}
+
+func (p testPayload) data() map[string]any {
+	return p["data"].(map[string]any)
+}
+
+func (p testPayload) personalInfo() map[string]any {
+	return p.data()["personalInfo"].(map[string]any)
+}
+
+func (p testPayload) settings() map[string]any {
+	return p["settings"].(map[string]any)
+}
+
+// fitPayload returns a payload with one role of bulletCount bullets that asks to fit on
+// one page.
+func fitPayload(bulletCount int) testPayload {
+	bullets := make([]string, 0, bulletCount)
+	for i := 0; i < bulletCount; i++ {
+		bullets = append(bullets, "Implemented concurrency-heavy backend systems and validated throughput under sustained load.")
+	}
+
+	payload := basePayload()
+	payload.personalInfo()["email"] = "ada@example.com"
+	delete(payload.data(), "technicalSkills")
+	payload.data()["experience"] = []map[string]any{{
+		"company":   "Engines Ltd",
+		"role":      "Backend Engineer",
+		"startDate": "2020",
+		"endDate":   "2024",
+		"bullets":   bullets,
+	}}
+	payload.settings()["fitToPages"] = 1
+	return payload
+}
+
+// postJSON posts payload as JSON to path and returns the recorded response.
+func postJSON(t *testing.T, router http.Handler, path string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func expectStatus(t *testing.T, rr *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rr.Code != want {
+		t.Fatalf("expected %d, got %d, body=%s", want, rr.Code, rr.Body.String())
+	}
+}
+
+func expectBodyContains(t *testing.T, rr *httptest.ResponseRecorder, fragments ...string) {
+	t.Helper()
+	body := rr.Body.String()
+	for _, fragment := range fragments {
+		if !strings.Contains(body, fragment) {
+			t.Fatalf("expected %s in response, got %s", fragment, body)
+		}
+	}
+}
+
+func expectBodyOmits(t *testing.T, rr *httptest.ResponseRecorder, fragments ...string) {
+	t.Helper()
+	body := rr.Body.String()
+	for _, fragment := range fragments {
+		if strings.Contains(body, fragment) {
+			t.Fatalf("expected no %s in response, got %s", fragment, body)
+		}
+	}
+}
+
+// warningKeys decodes a JSON array of warnings, such as X-Resume-Warnings, into
+// "code field" strings.
+func warningKeys(t *testing.T, raw string) []string {
+	t.Helper()
+
+	var warnings []struct {
+		Field string `json:"field"`
+		Code  string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(raw), &warnings); err != nil {
+		t.Fatalf("decode warnings %q: %v", raw, err)
+	}
+	keys := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		keys = append(keys, warning.Code+" "+warning.Field)
+	}
+	return keys
+}
+
+func TestGeneratePDFRendersTaggedVariant(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.personalInfo()["email"] = "ada@example.com"
+	payload.data()["experience"] = []map[string]any{{
+		"company": "Analytical Engines Ltd",
+		"role":    "Engineer",
+		"tags":    []string{"backend", "ml"},
+		"bullets": []any{
+			"Shared bullet.",
+			map[string]any{"text": "Built the card reader service.", "tags": []string{"backend"}},
+			map[string]any{"text": "Trained the Bernoulli model.", "tags": []string{"ml"}},
+		},
+	}}
+	payload.data()["projects"] = []map[string]any{{"name": "Bernoulli Numbers", "tags": []string{"ml"}}}
+	payload["variant"] = map[string]any{"name": "Backend", "includeTags": []string{"Backend", "platform"}}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	if disposition := rr.Header().Get("Content-Disposition"); !strings.Contains(disposition, "Ada_Lovelace_Backend_Resume.pdf") {
+		t.Fatalf("expected the variant name in the file name, got %q", disposition)
+	}
+	if warnings := rr.Header().Get("X-Resume-Warnings"); !strings.Contains(warnings, `"field":"variant.includeTags[1]","code":"unmatchedVariantTag"`) || strings.Contains(warnings, "includeTags[0]") {
+		t.Fatalf("expected only the unused platform tag to be reported, got %s", warnings)
+	}
+}
+
+func TestGeneratePDFValidationErrorForInvalidTags(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := basePayload()
+	payload.data()["experience"] = []map[string]any{{
+		"company": "Analytical Engines Ltd",
+		"roles": []map[string]any{{
+			"role":    "Engineer",
+			"tags":    []string{" "},
+			"bullets": []any{map[string]any{"text": "Built the card reader.", "tags": []string{strings.Repeat("x", 31)}}},
+		}},
+	}}
+	payload.data()["projects"] = []map[string]any{{"name": "Bernoulli Numbers", "tags": []string{"ml", ""}}}
+	payload["variant"] = map[string]any{"name": "Backend", "includeTags": []string{}}
+
+	rr := postJSON(t, router, generatePDFPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr,
+		`"field":"data.experience[0].roles[0].tags[0]","message":"must not be empty"`,
+		`"field":"data.experience[0].roles[0].bullets[0].tags[0]","message":"must be at most 30 characters"`,
+		`"field":"data.projects[0].tags[1]"`,
+		`"field":"variant.includeTags","message":"must list at least one tag"`,
+	)
+}
+
+func TestKeywordMatchEndpointReportsMatchedAndMissingKeywords(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := map[string]any{
+		"jobDescription": "Backend engineer to build distributed systems in Go. Distributed systems experience with Kafka and Kubernetes required; Ruby on Rails a plus.",
+		"data": map[string]any{
+			"personalInfo": map[string]any{"firstName": "Ada", "lastName": "Lovelace"},
+			"experience": []map[string]any{{
+				"company": "Analytical Engines Ltd",
+				"role":    "Engineer",
+				"bullets": []any{
+					"Scaled **distributed systems** to 1M requests per second.",
+					map[string]any{"text": "Moved batch jobs to Kafka.", "children": []string{"Rewrote the consumers in Go."}},
+				},
+			}},
+			"technicalSkills": []map[string]any{{"label": "Languages", "skills": []string{"Go", "Ruby on Rails"}}},
+		},
+	}
+
+	rr := postJSON(t, router, keywordMatchPath, payload)
+
+	expectStatus(t, rr, http.StatusOK)
+	var report struct {
+		Coverage float64 `json:"coverage"`
+		Matched  []struct {
+			Keyword string `json:"keyword"`
+			Count   int    `json:"count"`
+			Bullets []struct {
+				Field string `json:"field"`
+				Text  string `json:"text"`
+			} `json:"bullets"`
+			Skills []struct {
+				Field string `json:"field"`
+			} `json:"skills"`
+		} `json:"matched"`
+		Missing []struct {
+			Keyword string `json:"keyword"`
+		} `json:"missing"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+
+	matched := make([]string, 0, len(report.Matched))
+	for _, match := range report.Matched {
+		matched = append(matched, match.Keyword)
+	}
+	missing := make([]string, 0, len(report.Missing))
+	for _, keyword := range report.Missing {
+		missing = append(missing, keyword.Keyword)
+	}
+	if want := []string{"distributed systems", "go", "kafka", "ruby on rails"}; strings.Join(matched, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected matched keywords %v, got %v", want, matched)
+	}
+	if want := []string{"backend", "engineer", "kubernetes"}; strings.Join(missing, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected missing keywords %v, got %v", want, missing)
+	}
+	if report.Coverage != 57.1 {
+		t.Fatalf("expected 57.1%% coverage, got %v", report.Coverage)
+	}
+
+	systems := report.Matched[0]
+	if systems.Count != 2 || len(systems.Bullets) != 1 || systems.Bullets[0].Field != "data.experience[0].bullets[0]" ||
+		systems.Bullets[0].Text != "Scaled distributed systems to 1M requests per second." {
+		t.Fatalf("unexpected distributed systems match: %+v", systems)
+	}
+	golang := report.Matched[1]
+	if len(golang.Bullets) != 1 || golang.Bullets[0].Field != "data.experience[0].bullets[1].children[0]" ||
+		len(golang.Skills) != 1 || golang.Skills[0].Field != "data.technicalSkills[0].skills[0]" {
+		t.Fatalf("unexpected go match: %+v", golang)
+	}
+}
+
+func TestKeywordMatchEndpointValidationError(t *testing.T) {
+	router := handlers.NewRouter("1.0.0")
+	payload := map[string]any{
+		"jobDescription": "   ",
+		"data":           map[string]any{"personalInfo": map[string]any{"firstName": "Ada", "lastName": "Lovelace"}},
+	}
+
+	rr := postJSON(t, router, keywordMatchPath, payload)
+
+	expectStatus(t, rr, http.StatusBadRequest)
+	expectBodyContains(t, rr, `"field":"jobDescription","message":"must not be empty"`, `"field":"data"`)
+}
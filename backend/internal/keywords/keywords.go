@@ -0,0 +1,253 @@
+// Package keywords extracts the terms a job description stresses and finds them in
+// other text. Words are matched case-insensitively with simple plural folding, and
+// phrases never span punctuation such as commas or sentence ends.
+package keywords
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minPhraseCount is how often a multi-word phrase must occur in a job description to
+// be a keyword on its own, unless it is one of the known phrases.
+const minPhraseCount = 2
+
+// maxPhraseWords is the longest phrase considered.
+const maxPhraseWords = 3
+
+// Keyword is a word or phrase extracted from a text.
+type Keyword struct {
+	// Term is the keyword as first written in the text, in lower case.
+	Term string
+	// Count is how often the keyword occurs outside longer keywords.
+	Count int
+	keys  []string
+}
+
+// token is one word: its lower-case text and the key it is matched by.
+type token struct {
+	text string
+	key  string
+}
+
+// Document is text prepared for Contains.
+type Document struct {
+	segments [][]token
+}
+
+// NewDocument tokenizes text for matching.
+func NewDocument(text string) Document {
+	return Document{segments: segments(text)}
+}
+
+// Contains reports whether the words of keyword occur in d in order, within one run of
+// text between punctuation.
+func (d Document) Contains(keyword Keyword) bool {
+	for _, segment := range d.segments {
+		for start := 0; start+len(keyword.keys) <= len(segment); start++ {
+			if keysAt(segment, start, keyword.keys) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Extract returns up to limit keywords of text, most frequent first and then in order
+// of appearance. Single words are kept unless they are stop words or numbers. Phrases
+// of two or three such words are kept when they occur at least twice; phrases in known,
+// such as the skills listed on a resume, are kept whenever they occur, even across stop
+// words as in "ruby on rails". A word or phrase that only occurs inside a longer keyword
+// is not repeated on its own. A limit of zero or less keeps every keyword.
+func Extract(text string, known []string, limit int) []Keyword {
+	segs := segments(text)
+	knownKeys := make(map[string]bool, len(known))
+	for _, phrase := range known {
+		for _, segment := range segments(phrase) {
+			if len(segment) > 1 && len(segment) <= maxPhraseWords {
+				knownKeys[joinKeys(segment)] = true
+			}
+		}
+	}
+
+	type candidate struct {
+		keyword Keyword
+		first   int
+		starts  [][2]int
+	}
+	covered := make([][]bool, len(segs))
+	for index, segment := range segs {
+		covered[index] = make([]bool, len(segment))
+	}
+
+	var kept []candidate
+	for size := maxPhraseWords; size >= 1; size-- {
+		candidates := map[string]*candidate{}
+		var order []string
+		position := 0
+		for segIndex, segment := range segs {
+			for start := 0; start+size <= len(segment); start++ {
+				words := segment[start : start+size]
+				key := joinKeys(words)
+				if !isKeywordRun(words) && !knownKeys[key] {
+					continue
+				}
+				entry, ok := candidates[key]
+				if !ok {
+					texts := make([]string, len(words))
+					keys := make([]string, len(words))
+					for i, word := range words {
+						texts[i], keys[i] = word.text, word.key
+					}
+					entry = &candidate{keyword: Keyword{Term: strings.Join(texts, " "), keys: keys}, first: position + start}
+					candidates[key] = entry
+					order = append(order, key)
+				}
+				entry.starts = append(entry.starts, [2]int{segIndex, start})
+			}
+			position += len(segment)
+		}
+
+		for _, key := range order {
+			entry := candidates[key]
+			var uncovered [][2]int
+			for _, at := range entry.starts {
+				if !allCovered(covered[at[0]][at[1] : at[1]+size]) {
+					uncovered = append(uncovered, at)
+				}
+			}
+			if len(uncovered) == 0 || (size > 1 && len(uncovered) < minPhraseCount && !knownKeys[key]) {
+				continue
+			}
+			for _, at := range uncovered {
+				for i := at[1]; i < at[1]+size; i++ {
+					covered[at[0]][i] = true
+				}
+			}
+			entry.keyword.Count = len(uncovered)
+			kept = append(kept, *entry)
+		}
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].keyword.Count != kept[j].keyword.Count {
+			return kept[i].keyword.Count > kept[j].keyword.Count
+		}
+		return kept[i].first < kept[j].first
+	})
+	if limit > 0 && len(kept) > limit {
+		kept = kept[:limit]
+	}
+	keywords := make([]Keyword, 0, len(kept))
+	for _, entry := range kept {
+		keywords = append(keywords, entry.keyword)
+	}
+	return keywords
+}
+
+// segments splits text into runs of words between punctuation. Words keep the
+// characters of names such as "c++", "c#", "node.js" and ".net"; hyphens and slashes
+// separate words, so "real-time" reads as "real time" and "ci/cd" as "ci cd".
+func segments(text string) [][]token {
+	var segs [][]token
+	var segment []token
+	var word strings.Builder
+
+	endWord := func() {
+		if word.Len() == 0 {
+			return
+		}
+		text := strings.TrimSuffix(strings.Trim(word.String(), "'’"), "'s")
+		word.Reset()
+		if text != "" {
+			segment = append(segment, token{text: text, key: stem(text)})
+		}
+	}
+	endSegment := func() {
+		endWord()
+		if len(segment) > 0 {
+			segs = append(segs, segment)
+			segment = nil
+		}
+	}
+
+	runes := []rune(strings.ToLower(text))
+	for index, r := range runes {
+		next := rune(0)
+		if index+1 < len(runes) {
+			next = runes[index+1]
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		case (r == '+' || r == '#') && word.Len() > 0:
+			word.WriteRune(r)
+		case (r == '\'' || r == '’') && word.Len() > 0 && unicode.IsLetter(next):
+			word.WriteRune('\'')
+		case r == '.' && (unicode.IsLetter(next) || unicode.IsDigit(next)) && (word.Len() > 0 || index == 0 || unicode.IsSpace(runes[index-1])):
+			word.WriteRune(r)
+		case unicode.IsSpace(r) && r != '\n' && r != '\r', r == '-', r == '/', r == '&':
+			endWord()
+		default:
+			endSegment()
+		}
+	}
+	endSegment()
+	return segs
+}
+
+// stem folds common English plurals so "services" matches "service" and "libraries"
+// matches "library".
+func stem(word string) string {
+	switch {
+	case utf8.RuneCountInString(word) > 4 && strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case utf8.RuneCountInString(word) > 3 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+// isKeywordRun reports whether every word of a run can be part of a keyword.
+func isKeywordRun(words []token) bool {
+	for _, word := range words {
+		if isStopWord(word.text) || utf8.RuneCountInString(word.text) < 2 || isNumber(word.text) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumber(word string) bool {
+	return strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' && r != '+' }) < 0
+}
+
+func keysAt(segment []token, start int, keys []string) bool {
+	for i, key := range keys {
+		if segment[start+i].key != key {
+			return false
+		}
+	}
+	return true
+}
+
+func joinKeys(words []token) string {
+	keys := make([]string, len(words))
+	for i, word := range words {
+		keys[i] = word.key
+	}
+	return strings.Join(keys, " ")
+}
+
+func allCovered(positions []bool) bool {
+	for _, covered := range positions {
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
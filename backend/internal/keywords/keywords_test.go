@@ -0,0 +1,78 @@
+package keywords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func terms(keywords []Keyword) []string {
+	result := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		result = append(result, keyword.Term)
+	}
+	return result
+}
+
+func TestExtractDropsStopWordsAndRanksByFrequency(t *testing.T) {
+	keywords := Extract("We are looking for a strong engineer with 5+ years of Go. Go and Kubernetes experience required.", nil, 0)
+
+	want := []string{"go", "engineer", "kubernetes"}
+	if got := terms(keywords); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if keywords[0].Count != 2 {
+		t.Fatalf("expected go to be counted twice, got %d", keywords[0].Count)
+	}
+}
+
+func TestExtractKeepsRepeatedAndKnownPhrases(t *testing.T) {
+	text := "Design distributed systems. Scale distributed systems with Ruby on Rails and real-time pipelines."
+	keywords := Extract(text, []string{"Ruby on Rails", "Real Time"}, 0)
+
+	want := []string{"distributed systems", "design", "scale", "ruby on rails", "real time", "pipelines"}
+	if got := terms(keywords); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestExtractKeepsWordsUsedOutsidePhrases(t *testing.T) {
+	text := "Machine learning platform; machine learning models; machine vision"
+	keywords := Extract(text, nil, 0)
+
+	counts := map[string]int{}
+	for _, keyword := range keywords {
+		counts[keyword.Term] = keyword.Count
+	}
+	want := map[string]int{"machine learning": 2, "platform": 1, "models": 1, "machine": 1, "vision": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Fatalf("expected %v, got %v", want, counts)
+	}
+}
+
+func TestExtractHonorsLimit(t *testing.T) {
+	if got := terms(Extract("Python, Go, Rust, Java", nil, 2)); !reflect.DeepEqual(got, []string{"python", "go"}) {
+		t.Fatalf("expected the first two keywords, got %v", got)
+	}
+}
+
+func TestDocumentContains(t *testing.T) {
+	document := NewDocument("Migrated Node.js services to C++ and .NET; cut CI/CD time, Kafka streaming.")
+	keywordOf := func(text string) Keyword {
+		keywords := Extract(text, []string{text}, 0)
+		if len(keywords) != 1 {
+			t.Fatalf("expected one keyword in %q, got %v", text, terms(keywords))
+		}
+		return keywords[0]
+	}
+
+	for _, text := range []string{"node.js", "service", "c++", ".net", "ci cd", "Kafka streaming"} {
+		if !document.Contains(keywordOf(text)) {
+			t.Fatalf("expected document to contain %q", text)
+		}
+	}
+	for _, text := range []string{"net", "cd time kafka", "net cut"} {
+		if document.Contains(keywordOf(text)) {
+			t.Fatalf("expected document not to contain %q", text)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package keywords
+
+import "strings"
+
+// stopWords are common English words plus the boilerplate of job postings, neither of
+// which says anything about the skills a role needs.
+var stopWords = setOf(`
+a about above across after again against all almost along also although always am
+among an and another any anyone anything are around as at be became because become
+been before being below between both but by can cannot could did do does doing done
+down during e.g each either else enough especially etc even ever every everything few
+for from further get gets getting give given go's had has have having he her here hers
+herself him himself his how however i.e if in into is it its itself just least less
+like likely made make makes many may me might more most much must my myself near need
+needs neither never no nor not now of off often on once one only onto or other others
+our ours ourselves out over own per perhaps please quite rather really same several
+shall she should since so some something such than that the their theirs them
+themselves then there these they this those though through throughout thus to
+together too toward towards under until up upon us very via was we well were what
+whatever when where whether which while who whom whose why will with within without
+would yet you your yours yourself yourselves
+
+ability able additional apply applicant applicants applying based benefit
+benefits bonus build building candidate candidates closely collaborate collaborating
+company competitive comfortable contribute day days deliver delivering drive driving
+ensure grow growing improve improving owning support supporting thrive
+degree demonstrated desire desired duties environment equal employer employment
+equivalent excellent exceptional excited experience experienced familiar familiarity
+field full good great help highly ideal ideally include includes including job join
+key knowledge level looking member members minimum new nice opportunity opportunities
+part passion passionate plus position preferred proficiency proficient proven
+qualification qualifications related relevant required requirement requirements
+responsibilities responsibility responsible role roles salary seeking seeks skill
+skills solid strong successful team teams understanding using work working world year
+years`)
+
+func setOf(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// isStopWord reports whether word, in lower case, is a stop word.
+func isStopWord(word string) bool {
+	return stopWords[word]
+}
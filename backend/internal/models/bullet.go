@@ -0,0 +1,65 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MaxBulletDepth is the deepest nesting level accepted for sub-bullets; top-level
+// bullets are level 1.
+const MaxBulletDepth = 3
+
+// Bullet styles accepted in ResumeSetting.BulletStyle and template definitions.
+const (
+	BulletDisc   = "disc"
+	BulletDash   = "dash"
+	BulletSquare = "square"
+)
+
+// BulletStyles lists every bullet style.
+var BulletStyles = []string{BulletDisc, BulletDash, BulletSquare}
+
+// Bullet is one bullet point with optional sub-bullets and variant tags. In JSON a
+// bullet without children or tags may be written as a plain string, so flat bullet lists
+// stay valid.
+type Bullet struct {
+	Text     string
+	Children []Bullet
+	Tags     []string
+}
+
+type bulletObject struct {
+	Text     string   `json:"text"`
+	Children []Bullet `json:"children,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// MarshalJSON writes a bullet without children or tags as a string and others as an
+// object.
+func (b Bullet) MarshalJSON() ([]byte, error) {
+	if len(b.Children) == 0 && len(b.Tags) == 0 {
+		return json.Marshal(b.Text)
+	}
+	return json.Marshal(bulletObject{Text: b.Text, Children: b.Children, Tags: b.Tags})
+}
+
+// UnmarshalJSON accepts a bullet string or a {"text","children","tags"} object.
+func (b *Bullet) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '"' {
+		var text string
+		if err := json.Unmarshal(trimmed, &text); err != nil {
+			return err
+		}
+		*b = Bullet{Text: text}
+		return nil
+	}
+
+	var object bulletObject
+	if err := json.Unmarshal(trimmed, &object); err != nil {
+		return fmt.Errorf("bullet must be a string or an object with text and children: %w", err)
+	}
+	*b = Bullet{Text: object.Text, Children: object.Children, Tags: object.Tags}
+	return nil
+}
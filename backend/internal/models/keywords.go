@@ -0,0 +1,37 @@
+package models
+
+// KeywordMatchRequest is the payload of the keyword match endpoint: a job description
+// to compare with the bullets and technical skills of Data.
+type KeywordMatchRequest struct {
+	JobDescription string     `json:"jobDescription"`
+	Data           ResumeData `json:"data"`
+}
+
+// JobKeyword is a word or phrase extracted from a job description, with how often the
+// description uses it.
+type JobKeyword struct {
+	Keyword string `json:"keyword"`
+	Count   int    `json:"count"`
+}
+
+// KeywordSource is a bullet or skill that contains a keyword, as plain text.
+type KeywordSource struct {
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+// KeywordMatch is a job keyword found in the resume, with every bullet and skill that
+// contains it.
+type KeywordMatch struct {
+	JobKeyword
+	Bullets []KeywordSource `json:"bullets"`
+	Skills  []KeywordSource `json:"skills"`
+}
+
+// KeywordMatchReport compares a job description with a resume. Coverage is the share of
+// keywords found in the resume, as a percentage rounded to one decimal.
+type KeywordMatchReport struct {
+	Coverage float64        `json:"coverage"`
+	Matched  []KeywordMatch `json:"matched"`
+	Missing  []JobKeyword   `json:"missing"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Page sizes accepted in ResumeSetting.PageSize, matched case-insensitively.
+const (
+	PageSizeA4     = "A4"
+	PageSizeLetter = "Letter"
+	PageSizeLegal  = "Legal"
+)
+
+// Margin presets accepted in ResumeSetting.Margins.
+const (
+	MarginsNarrow = "narrow"
+	MarginsNormal = "normal"
+	MarginsWide   = "wide"
+)
+
+// NormalizePageSize returns the canonical spelling of size and whether it is supported.
+func NormalizePageSize(size string) (string, bool) {
+	for _, known := range []string{PageSizeA4, PageSizeLetter, PageSizeLegal} {
+		if strings.EqualFold(strings.TrimSpace(size), known) {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// MarginSetting is either a named preset or explicit page margins in millimetres. In
+// JSON it is a preset string such as "narrow" or a {"top","right","bottom","left"} object;
+// a side left out of the object is nil and keeps the template margin.
+type MarginSetting struct {
+	Preset string
+	Top    *float64
+	Right  *float64
+	Bottom *float64
+	Left   *float64
+}
+
+type marginValues struct {
+	Top    *float64 `json:"top,omitempty"`
+	Right  *float64 `json:"right,omitempty"`
+	Bottom *float64 `json:"bottom,omitempty"`
+	Left   *float64 `json:"left,omitempty"`
+}
+
+// MarshalJSON writes a preset as a string and explicit margins as an object.
+func (m MarginSetting) MarshalJSON() ([]byte, error) {
+	if m.Preset != "" {
+		return json.Marshal(m.Preset)
+	}
+	return json.Marshal(marginValues{Top: m.Top, Right: m.Right, Bottom: m.Bottom, Left: m.Left})
+}
+
+// UnmarshalJSON accepts a preset string or an object of explicit margins.
+func (m *MarginSetting) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '"' {
+		var preset string
+		if err := json.Unmarshal(trimmed, &preset); err != nil {
+			return err
+		}
+		*m = MarginSetting{Preset: preset}
+		return nil
+	}
+
+	var values marginValues
+	if err := json.Unmarshal(trimmed, &values); err != nil {
+		return fmt.Errorf("margins must be a preset name or an object of millimetre values: %w", err)
+	}
+	*m = MarginSetting{Top: values.Top, Right: values.Right, Bottom: values.Bottom, Left: values.Left}
+	return nil
+}
@@ -1,10 +1,43 @@
 package models
 
+// Section keys identify the built-in resume sections in template definitions and settings.
+const (
+	SectionEducation       = "education"
+	SectionExperience      = "experience"
+	SectionProjects        = "projects"
+	SectionTechnicalSkills = "technicalSkills"
+	SectionCustom          = "customSections"
+)
+
+// SectionKeys lists every valid section key in the classic rendering order.
+var SectionKeys = []string{SectionEducation, SectionExperience, SectionProjects, SectionTechnicalSkills, SectionCustom}
+
+// IsSectionKey reports whether key names a known resume section.
+func IsSectionKey(key string) bool {
+	for _, known := range SectionKeys {
+		if key == known {
+			return true
+		}
+	}
+	return false
+}
+
 // GeneratePDFRequest is the payload consumed by the v1 PDF endpoint.
 type GeneratePDFRequest struct {
-	Data     ResumeData    `json:"data"`
-	Settings ResumeSetting `json:"settings"`
-	Photo    string        `json:"photo,omitempty"`
+	TemplateID string        `json:"templateId,omitempty"`
+	Data       ResumeData    `json:"data"`
+	Settings   ResumeSetting `json:"settings"`
+	Photo      string        `json:"photo,omitempty"`
+	// Variant, when set, renders only the tagged entries and bullets it includes.
+	Variant *ResumeVariant `json:"variant,omitempty"`
+}
+
+// ResumeVariant tailors one ResumeData to a kind of role. Experience entries, roles,
+// projects and bullets tagged with none of IncludeTags are left out; untagged ones are
+// shared by every variant. Tags match case-insensitively.
+type ResumeVariant struct {
+	Name        string   `json:"name,omitempty"`
+	IncludeTags []string `json:"includeTags"`
 }
 
 // ResumeData contains all resume sections used by both preview and PDF rendering.
@@ -14,12 +47,15 @@ type ResumeData struct {
 	Education       []EducationEntry  `json:"education,omitempty"`
 	Projects        []ProjectEntry    `json:"projects,omitempty"`
 	TechnicalSkills TechnicalSkills   `json:"technicalSkills,omitempty"`
+	CustomSections  []CustomSection   `json:"customSections,omitempty"`
 }
 
 // PersonalInfo is the header content for the resume.
 type PersonalInfo struct {
 	FirstName  string         `json:"firstName"`
 	LastName   string         `json:"lastName"`
+	Headline   string         `json:"headline,omitempty"`
+	Summary    string         `json:"summary,omitempty"`
 	Location   string         `json:"location,omitempty"`
 	Phone      string         `json:"phone,omitempty"`
 	Email      string         `json:"email,omitempty"`
@@ -36,44 +72,98 @@ type PersonalLink struct {
 	URL   string `json:"url,omitempty"`
 }
 
-// ExperienceEntry represents a single role in the experience section.
+// ExperienceEntry represents a single role in the experience section. When Roles is set
+// the entry groups several roles under one company and location, and the entry's own
+// Role, dates, employment type and Bullets must be empty.
 type ExperienceEntry struct {
-	ID        string   `json:"id,omitempty"`
-	Company   string   `json:"company"`
-	Location  string   `json:"location,omitempty"`
-	Role      string   `json:"role"`
-	StartDate string   `json:"startDate,omitempty"`
-	EndDate   string   `json:"endDate,omitempty"`
-	Bullets   []string `json:"bullets,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Company string `json:"company"`
+	// URL is the company's website; the company name links to it.
+	URL       string `json:"url,omitempty"`
+	Location  string `json:"location,omitempty"`
+	Role      string `json:"role"`
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+	// EmploymentType is one of EmploymentTypes; empty counts as full-time.
+	EmploymentType string           `json:"employmentType,omitempty"`
+	Tags           []string         `json:"tags,omitempty"`
+	Bullets        []Bullet         `json:"bullets,omitempty"`
+	Roles          []ExperienceRole `json:"roles,omitempty"`
+}
+
+// ExperienceRole is one position held at the company of a grouped ExperienceEntry.
+type ExperienceRole struct {
+	ID             string   `json:"id,omitempty"`
+	Role           string   `json:"role"`
+	StartDate      string   `json:"startDate,omitempty"`
+	EndDate        string   `json:"endDate,omitempty"`
+	EmploymentType string   `json:"employmentType,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Bullets        []Bullet `json:"bullets,omitempty"`
 }
 
+// Employment types accepted on experience entries and roles. Only full-time roles are
+// checked for overlaps.
+const (
+	EmploymentFullTime   = "fullTime"
+	EmploymentPartTime   = "partTime"
+	EmploymentContract   = "contract"
+	EmploymentInternship = "internship"
+	EmploymentFreelance  = "freelance"
+)
+
+// EmploymentTypes lists every employment type.
+var EmploymentTypes = []string{EmploymentFullTime, EmploymentPartTime, EmploymentContract, EmploymentInternship, EmploymentFreelance}
+
 // EducationEntry represents a single education entry.
 type EducationEntry struct {
-	ID          string   `json:"id,omitempty"`
-	Institution string   `json:"institution"`
-	Location    string   `json:"location,omitempty"`
-	Degree      string   `json:"degree"`
-	StartDate   string   `json:"startDate,omitempty"`
-	EndDate     string   `json:"endDate,omitempty"`
-	Bullets     []string `json:"bullets,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Institution string `json:"institution"`
+	Location    string `json:"location,omitempty"`
+	Degree      string `json:"degree"`
+	Minor       string `json:"minor,omitempty"`
+	// GPA is shown as entered, such as "3.85"; when GPAScale is set, such as "4.0" or
+	// "10", GPA must not exceed it.
+	GPA                string   `json:"gpa,omitempty"`
+	GPAScale           string   `json:"gpaScale,omitempty"`
+	Honors             string   `json:"honors,omitempty"`
+	RelevantCoursework []string `json:"relevantCoursework,omitempty"`
+	StartDate          string   `json:"startDate,omitempty"`
+	EndDate            string   `json:"endDate,omitempty"`
+	Bullets            []Bullet `json:"bullets,omitempty"`
 }
 
 // ProjectEntry represents a project in the projects section.
 type ProjectEntry struct {
-	ID        string   `json:"id,omitempty"`
-	Name      string   `json:"name"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// URL is the project's live site or write-up; the project name links to it.
+	URL string `json:"url,omitempty"`
+	// RepoURL is the project's source repository, shown as a link token such as
+	// "[GitHub]" after the name.
+	RepoURL   string   `json:"repoUrl,omitempty"`
 	TechStack string   `json:"techStack,omitempty"`
 	StartDate string   `json:"startDate,omitempty"`
 	EndDate   string   `json:"endDate,omitempty"`
-	Bullets   []string `json:"bullets,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Bullets   []Bullet `json:"bullets,omitempty"`
 }
 
-// TechnicalSkills stores categorized technical skills.
-type TechnicalSkills struct {
-	Languages      string `json:"languages,omitempty"`
-	Frameworks     string `json:"frameworks,omitempty"`
-	DeveloperTools string `json:"developerTools,omitempty"`
-	Libraries      string `json:"libraries,omitempty"`
+// CustomSection is a user-titled section such as certifications, awards or publications.
+type CustomSection struct {
+	ID      string        `json:"id,omitempty"`
+	Title   string        `json:"title"`
+	Entries []CustomEntry `json:"entries,omitempty"`
+}
+
+// CustomEntry is a single item inside a custom section.
+type CustomEntry struct {
+	ID        string   `json:"id,omitempty"`
+	Primary   string   `json:"primary,omitempty"`
+	Secondary string   `json:"secondary,omitempty"`
+	Date      string   `json:"date,omitempty"`
+	Location  string   `json:"location,omitempty"`
+	Bullets   []Bullet `json:"bullets,omitempty"`
 }
 
 // ResumeSetting configures PDF rendering options.
@@ -81,6 +171,121 @@ type ResumeSetting struct {
 	ShowPhoto  bool   `json:"showPhoto"`
 	FontSize   string `json:"fontSize"`
 	FontFamily string `json:"fontFamily"`
+	// SectionOrder overrides the template section order; omitted sections follow in template order.
+	SectionOrder []string `json:"sectionOrder,omitempty"`
+	// SectionVisibility hides a section when its key maps to false.
+	SectionVisibility map[string]bool `json:"sectionVisibility,omitempty"`
+	// FitToPages, when positive, tightens the layout until the resume fits that many pages.
+	FitToPages int `json:"fitToPages,omitempty"`
+	// PageSize selects A4, Letter, or Legal paper; empty keeps A4.
+	PageSize string `json:"pageSize,omitempty"`
+	// Margins overrides the template page margins with a preset or explicit values.
+	Margins *MarginSetting `json:"margins,omitempty"`
+	// Theme overrides the template colors with a preset and optional hex colors.
+	Theme *ThemeSetting `json:"theme,omitempty"`
+	// HeaderLayout arranges the name, contacts, and photo; empty means centered.
+	HeaderLayout string `json:"headerLayout,omitempty"`
+	// ShortenLinks shows contact links without their scheme, "www." prefix, or trailing slash.
+	ShortenLinks bool `json:"shortenLinks,omitempty"`
+	// ContactIcons draws a small icon before each contact detail.
+	ContactIcons bool `json:"contactIcons,omitempty"`
+	// BulletStyle selects the bullet glyph (disc, dash, or square); empty keeps the template's.
+	BulletStyle string `json:"bulletStyle,omitempty"`
+	// DateFormat renders entry dates in one style; empty keeps dates as entered.
+	DateFormat string `json:"dateFormat,omitempty"`
+	// SortEntries orders experience, education and projects; empty keeps them as sent.
+	SortEntries string `json:"sortEntries,omitempty"`
+	// GapWarningMonths is the longest gap between roles or studies that is not reported
+	// as a warning; zero means DefaultGapWarningMonths.
+	GapWarningMonths int `json:"gapWarningMonths,omitempty"`
+}
+
+// Header layouts accepted in ResumeSetting.HeaderLayout.
+const (
+	HeaderCentered  = "centered"
+	HeaderLeft      = "left"
+	HeaderSplit     = "split"
+	HeaderPhotoLeft = "photoLeft"
+)
+
+// HeaderLayouts lists every header layout.
+var HeaderLayouts = []string{HeaderCentered, HeaderLeft, HeaderSplit, HeaderPhotoLeft}
+
+// Date formats accepted in ResumeSetting.DateFormat: "Jan 2022", "January 2022",
+// "01/2022", "2022-01", and "2022".
+const (
+	DateFormatShort   = "short"
+	DateFormatLong    = "long"
+	DateFormatNumeric = "numeric"
+	DateFormatISO     = "iso"
+	DateFormatYear    = "year"
+)
+
+// DateFormats lists every date format.
+var DateFormats = []string{DateFormatShort, DateFormatLong, DateFormatNumeric, DateFormatISO, DateFormatYear}
+
+// Entry orders accepted in ResumeSetting.SortEntries. Reverse chronological puts the
+// latest end date first, with ongoing entries ahead of finished ones.
+const SortReverseChronological = "reverseChronological"
+
+// SortOrders lists every entry order.
+var SortOrders = []string{SortReverseChronological}
+
+// DefaultGapWarningMonths is the gap length reported when settings.gapWarningMonths is unset.
+const DefaultGapWarningMonths = 6
+
+// GeneratedPDF is a rendered resume together with details about how it was laid out.
+type GeneratedPDF struct {
+	Content  []byte
+	Pages    int
+	Fit      *FitReport
+	Warnings []ValidationWarning
+}
+
+// FitReport describes the adjustments applied to honour settings.fitToPages.
+type FitReport struct {
+	TargetPages int             `json:"targetPages"`
+	Pages       int             `json:"pages"`
+	Adjustments []FitAdjustment `json:"adjustments"`
+}
+
+// FitAdjustment records one layout property that was tightened to fit the page target.
+type FitAdjustment struct {
+	Property string  `json:"property"`
+	From     float64 `json:"from"`
+	To       float64 `json:"to"`
+}
+
+// LayoutReport describes how a resume lays out across pages without producing PDF bytes.
+// Heights and Y positions are in millimetres from the top edge of the page.
+type LayoutReport struct {
+	Pages           int                 `json:"pages"`
+	FilledPages     float64             `json:"filledPages"`
+	UsableHeight    float64             `json:"usableHeight"`
+	RemainingHeight float64             `json:"remainingHeight"`
+	Sections        []SectionPlacement  `json:"sections"`
+	Fit             *FitReport          `json:"fit,omitempty"`
+	Warnings        []ValidationWarning `json:"warnings,omitempty"`
+}
+
+// SectionPlacement is where a section title lands, with the placements of its entries.
+type SectionPlacement struct {
+	Key     string           `json:"key"`
+	Title   string           `json:"title"`
+	Page    int              `json:"page"`
+	Y       float64          `json:"y"`
+	Entries []EntryPlacement `json:"entries,omitempty"`
+}
+
+// EntryPlacement is where a section entry starts and ends.
+type EntryPlacement struct {
+	Index   int     `json:"index"`
+	ID      string  `json:"id,omitempty"`
+	Label   string  `json:"label"`
+	Page    int     `json:"page"`
+	Y       float64 `json:"y"`
+	EndPage int     `json:"endPage"`
+	EndY    float64 `json:"endY"`
 }
 
 // ValidationErrorDetail maps a concrete field to a validation failure.
@@ -88,3 +293,32 @@ type ValidationErrorDetail struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
+
+// Warning codes reported in ValidationWarning.Code.
+const (
+	WarningOverlappingRoles    = "overlappingRoles"
+	WarningTimelineGap         = "timelineGap"
+	WarningFutureStartDate     = "futureStartDate"
+	WarningMissingEmail        = "missingEmail"
+	WarningLongBullet          = "longBullet"
+	WarningInconsistentPeriods = "inconsistentPeriods"
+	WarningLinkWithoutScheme   = "linkWithoutScheme"
+	WarningOverflow            = "overflow"
+	WarningUnmatchedTag        = "unmatchedVariantTag"
+	WarningUnparsedDate        = "unparsedDate"
+)
+
+// ValidationWarning flags a likely mistake in a field without blocking generation.
+type ValidationWarning struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationReport is the outcome of validating a request without rendering it. Errors
+// block generation; warnings do not.
+type ValidationReport struct {
+	Valid    bool                    `json:"valid"`
+	Errors   []ValidationErrorDetail `json:"errors"`
+	Warnings []ValidationWarning     `json:"warnings"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TechnicalSkills is the ordered list of skill categories shown in the technical skills
+// section. In JSON it is an array of categories; the legacy object of four
+// comma-separated fields (languages, frameworks, developerTools, libraries) is still
+// accepted and decodes to categories in that order.
+type TechnicalSkills []SkillCategory
+
+// SkillCategory is one user-labelled group of skills, such as "Languages" or "ML/AI".
+type SkillCategory struct {
+	ID     string   `json:"id,omitempty"`
+	Label  string   `json:"label"`
+	Skills []string `json:"skills"`
+}
+
+type legacyTechnicalSkills struct {
+	Languages      string `json:"languages,omitempty"`
+	Frameworks     string `json:"frameworks,omitempty"`
+	DeveloperTools string `json:"developerTools,omitempty"`
+	Libraries      string `json:"libraries,omitempty"`
+}
+
+// UnmarshalJSON accepts an array of categories or the legacy four-field object.
+func (s *TechnicalSkills) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		var categories []SkillCategory
+		if err := json.Unmarshal(trimmed, &categories); err != nil {
+			return fmt.Errorf("technical skills must be an array of categories: %w", err)
+		}
+		*s = categories
+		return nil
+	}
+
+	var legacy legacyTechnicalSkills
+	if err := json.Unmarshal(trimmed, &legacy); err != nil {
+		return fmt.Errorf("legacy technical skills fields must be strings: %w", err)
+	}
+	var categories TechnicalSkills
+	for _, field := range []struct{ label, value string }{
+		{"Languages", legacy.Languages},
+		{"Frameworks", legacy.Frameworks},
+		{"Developer Tools", legacy.DeveloperTools},
+		{"Libraries", legacy.Libraries},
+	} {
+		if skills := splitSkills(field.value); len(skills) > 0 {
+			categories = append(categories, SkillCategory{Label: field.label, Skills: skills})
+		}
+	}
+	*s = categories
+	return nil
+}
+
+// HasSkills reports whether any category lists a non-blank skill.
+func (s TechnicalSkills) HasSkills() bool {
+	for _, category := range s {
+		if len(category.Items()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Items returns the category's skills trimmed, without blank entries.
+func (c SkillCategory) Items() []string {
+	var items []string
+	for _, skill := range c.Skills {
+		if trimmed := strings.TrimSpace(skill); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
+
+// splitSkills splits a comma-separated skill list, dropping blank items.
+func splitSkills(value string) []string {
+	var skills []string
+	for _, skill := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(skill); trimmed != "" {
+			skills = append(skills, trimmed)
+		}
+	}
+	return skills
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Theme presets accepted in ThemeSetting.Preset.
+const (
+	ThemeClassic    = "classic"
+	ThemeMonochrome = "monochrome"
+	ThemeNavy       = "navy"
+	ThemeForest     = "forest"
+	ThemeBurgundy   = "burgundy"
+)
+
+// ThemePresets lists every theme preset name.
+var ThemePresets = []string{ThemeClassic, ThemeMonochrome, ThemeNavy, ThemeForest, ThemeBurgundy}
+
+// ThemeSetting selects a color preset and optionally overrides single colors with
+// #rrggbb values. Accent colors the name and section titles, rule the dividers and
+// photo ring.
+type ThemeSetting struct {
+	Preset string `json:"preset,omitempty"`
+	Accent string `json:"accent,omitempty"`
+	Text   string `json:"text,omitempty"`
+	Link   string `json:"link,omitempty"`
+	Rule   string `json:"rule,omitempty"`
+}
+
+// IsThemePreset reports whether name is a known theme preset, ignoring case.
+func IsThemePreset(name string) bool {
+	for _, preset := range ThemePresets {
+		if strings.EqualFold(strings.TrimSpace(name), preset) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHexColor parses a #rrggbb color into its red, green, and blue components.
+func ParseHexColor(value string) (r, g, b int, ok bool) {
+	trimmed := strings.TrimSpace(value)
+	if len(trimmed) != 7 || trimmed[0] != '#' {
+		return 0, 0, 0, false
+	}
+	parsed, err := strconv.ParseUint(trimmed[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(parsed >> 16 & 0xff), int(parsed >> 8 & 0xff), int(parsed & 0xff), true
+}
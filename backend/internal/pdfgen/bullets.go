@@ -0,0 +1,91 @@
+package pdfgen
+
+import (
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+)
+
+// bulletGlyphs maps each bullet style to the glyph drawn in the bullet gutter. Every
+// embedded font covers these code points.
+var bulletGlyphs = map[string]string{
+	models.BulletDisc:   "\u2022",
+	models.BulletDash:   "\u2013",
+	models.BulletSquare: "\u25AA",
+}
+
+// bulletGutterWidth is the width reserved for the bullet glyph, and the indent of each
+// nesting level, at fontSize. It does not depend on the glyph so every style lines up.
+func bulletGutterWidth(fontSize float64) float64 {
+	return fontSize * 0.32
+}
+
+// applyBulletSettings returns layout with the bullet style requested in settings.
+// Unknown styles keep the template default; the service rejects them before rendering.
+func applyBulletSettings(layout layoutConfig, settings models.ResumeSetting) layoutConfig {
+	style := strings.ToLower(strings.TrimSpace(settings.BulletStyle))
+	if _, ok := bulletGlyphs[style]; ok {
+		layout.bulletStyle = style
+	}
+	return layout
+}
+
+// writeBullet draws bullet at nesting depth (0 for top-level bullets) followed by its
+// children one level deeper. The glyph sits in a fixed-width gutter and wrapped lines
+// hang under the text. Inline bold, italic, and link markup is honoured.
+func writeBullet(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, bullet models.Bullet, depth int, layout layoutConfig) {
+	trimmed := strings.TrimSpace(bullet.Text)
+	if trimmed == "" {
+		return
+	}
+
+	markerX, textLayout := bulletTextLayout(fontSize, depth, layout)
+	ensureSpace(pdf, layout.lineHeight, layout)
+	pdf.SetFont(fontFamily, "", fontSize)
+	pdf.SetX(markerX)
+	pdf.CellFormat(textLayout.leftMargin-markerX, layout.lineHeight, bulletGlyphs[layout.bulletStyle], "", 0, "L", false, 0, "")
+
+	spans, styled := parseInlineText(trimmed)
+	if styled {
+		writeInlineText(pdf, fontFamily, fontSize, spans, textLayout)
+	} else {
+		writeWrappedText(pdf, fontFamily, "", fontSize, spans[0].Text, textLayout)
+	}
+
+	for _, child := range bullet.Children {
+		writeBullet(pdf, fontFamily, fontSize, child, depth+1, layout)
+	}
+}
+
+// measureBullet returns the height writeBullet uses for text at depth, without children.
+func measureBullet(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, text string, depth int, layout layoutConfig) float64 {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return 0
+	}
+
+	_, textLayout := bulletTextLayout(fontSize, depth, layout)
+	spans, styled := parseInlineText(trimmed)
+	if !styled {
+		return measureWrappedText(pdf, fontFamily, "", fontSize, spans[0].Text, textLayout)
+	}
+	lines := layoutInlineText(pdf, fontFamily, fontSize, spans, textLayout.contentWidth())
+	return float64(len(lines)) * layout.lineHeight
+}
+
+// bulletTextLayout returns where the glyph of a bullet at depth starts and the layout its
+// text wraps within. Each level starts where its parent's text does; levels deeper than
+// models.MaxBulletDepth share the deepest indent.
+func bulletTextLayout(fontSize float64, depth int, layout layoutConfig) (float64, layoutConfig) {
+	if depth > models.MaxBulletDepth-1 {
+		depth = models.MaxBulletDepth - 1
+	}
+	gutter := bulletGutterWidth(fontSize)
+	markerX := layout.leftMargin + float64(depth)*gutter
+
+	textLayout := layout
+	textLayout.leftMargin = markerX + gutter
+	return markerX, textLayout
+}
@@ -0,0 +1,145 @@
+package pdfgen
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestBulletJSONAcceptsStringsAndTrees(t *testing.T) {
+	var entry models.ExperienceEntry
+	payload := `{"role":"Engineer","bullets":["Flat bullet",{"text":"Parent","children":["Child",{"text":"Grandchild parent","children":["Grandchild"]}]}]}`
+	if err := json.Unmarshal([]byte(payload), &entry); err != nil {
+		t.Fatalf("unmarshal bullets: %v", err)
+	}
+
+	want := []models.Bullet{
+		{Text: "Flat bullet"},
+		{Text: "Parent", Children: []models.Bullet{
+			{Text: "Child"},
+			{Text: "Grandchild parent", Children: []models.Bullet{{Text: "Grandchild"}}},
+		}},
+	}
+	got, _ := json.Marshal(entry.Bullets)
+	expected, _ := json.Marshal(want)
+	if string(got) != string(expected) {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+	if !strings.HasPrefix(string(got), `["Flat bullet",{"text":"Parent"`) {
+		t.Fatalf("expected childless bullets to marshal as strings, got %s", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"bullets":[{"text":"Tagged","tags":["ml"]}]}`), &entry); err != nil {
+		t.Fatalf("unmarshal tagged bullet: %v", err)
+	}
+	if got, _ := json.Marshal(entry.Bullets); string(got) != `[{"text":"Tagged","tags":["ml"]}]` {
+		t.Fatalf("expected tagged bullets to keep their tags, got %s", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"bullets":[42]}`), &entry); err == nil {
+		t.Fatal("expected a number bullet to be rejected")
+	}
+}
+
+func TestNestedBulletsHangUnderTheirText(t *testing.T) {
+	layout := defaultLayout()
+	pdf := newPaginationPDF(layout)
+
+	topMarker, top := bulletTextLayout(11, 0, layout)
+	childMarker, child := bulletTextLayout(11, 1, layout)
+	_, deepest := bulletTextLayout(11, models.MaxBulletDepth+2, layout)
+
+	if topMarker != layout.leftMargin {
+		t.Fatalf("expected top-level marker at the left margin, got %v", topMarker)
+	}
+	if childMarker != top.leftMargin || child.leftMargin != top.leftMargin+bulletGutterWidth(11) {
+		t.Fatalf("expected child bullet indented one level, got marker %v text %v", childMarker, child.leftMargin)
+	}
+	if top.leftMargin <= topMarker {
+		t.Fatalf("expected text to start after the marker gutter, got %v", top.leftMargin)
+	}
+	_, third := bulletTextLayout(11, models.MaxBulletDepth-1, layout)
+	if deepest.leftMargin != third.leftMargin {
+		t.Fatalf("expected levels past the maximum to share the deepest indent, got %v vs %v", deepest.leftMargin, third.leftMargin)
+	}
+
+	long := strings.Repeat("Wrapped bullet text that continues across several lines. ", 6)
+	if measureBullet(pdf, "Times", 11, long, 2, layout) < measureBullet(pdf, "Times", 11, long, 0, layout) {
+		t.Fatal("expected deeper bullets to wrap at least as often as top-level bullets")
+	}
+}
+
+func TestNestedBulletsRenderChildren(t *testing.T) {
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Experience: []models.ExperienceEntry{{
+				Role:    "Analyst",
+				Company: "Engines Ltd",
+				Bullets: []models.Bullet{{Text: "Parent"}},
+			}},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium"},
+	}
+	flat, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	req.Data.Experience[0].Bullets[0].Children = []models.Bullet{{Text: "Child one"}, {Text: "Child two", Children: []models.Bullet{{Text: "Grandchild"}}}}
+	nested, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	flatEntry := flat.Sections[0].Entries[0]
+	nestedEntry := nested.Sections[0].Entries[0]
+	lineHeight := defaultLayout().lineHeight
+	if got := (nestedEntry.EndY - nestedEntry.Y) - (flatEntry.EndY - flatEntry.Y); got < 3*lineHeight-0.01 {
+		t.Fatalf("expected three extra lines for the sub-bullets, got %vmm", got)
+	}
+}
+
+func TestApplyBulletSettings(t *testing.T) {
+	base := defaultLayout()
+
+	if got := applyBulletSettings(base, models.ResumeSetting{}); got.bulletStyle != models.BulletDisc {
+		t.Fatalf("expected template bullet style without settings, got %q", got.bulletStyle)
+	}
+	if got := applyBulletSettings(base, models.ResumeSetting{BulletStyle: " Square "}); got.bulletStyle != models.BulletSquare {
+		t.Fatalf("expected square bullets, got %q", got.bulletStyle)
+	}
+	if got := applyBulletSettings(base, models.ResumeSetting{BulletStyle: "star"}); got.bulletStyle != models.BulletDisc {
+		t.Fatalf("expected unknown style to keep the template default, got %q", got.bulletStyle)
+	}
+}
+
+func TestEveryBulletStyleHasAGlyph(t *testing.T) {
+	for _, style := range models.BulletStyles {
+		if bulletGlyphs[style] == "" {
+			t.Fatalf("bullet style %q has no glyph", style)
+		}
+	}
+}
+
+func TestBulletGutterDoesNotDependOnGlyph(t *testing.T) {
+	long := strings.Repeat("Measured the same wrapped bullet under every glyph style. ", 4)
+	var heights []float64
+	for _, style := range models.BulletStyles {
+		layout := defaultLayout()
+		layout.bulletStyle = style
+		pdf := newPaginationPDF(layout)
+		if err := registerResumeFonts(pdf); err != nil {
+			t.Fatalf("register fonts: %v", err)
+		}
+		writeBullet(pdf, fontFamilyTimes, 11, models.Bullet{Text: long}, 0, layout)
+		heights = append(heights, pdf.GetY())
+	}
+	for _, height := range heights[1:] {
+		if height != heights[0] {
+			t.Fatalf("expected every bullet style to wrap identically, got %v", heights)
+		}
+	}
+}
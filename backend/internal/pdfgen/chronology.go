@@ -0,0 +1,81 @@
+package pdfgen
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"resume_maker/backend/internal/dates"
+	"resume_maker/backend/internal/models"
+)
+
+// sortEntryBlocks reorders blocks in place by order, one of models.SortOrders; any other
+// order keeps blocks as sent.
+func sortEntryBlocks(blocks []entryBlock, order string) {
+	if !isReverseChronological(order) {
+		return
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return laterFirst(blocks[i].start, blocks[i].end, blocks[j].start, blocks[j].end)
+	})
+}
+
+// sortRoleBlocks reorders the roles of a grouped entry like sortEntryBlocks.
+func sortRoleBlocks(roles []roleBlock, order string) {
+	if !isReverseChronological(order) {
+		return
+	}
+	sort.SliceStable(roles, func(i, j int) bool {
+		return laterFirst(roles[i].start, roles[i].end, roles[j].start, roles[j].end)
+	})
+}
+
+func isReverseChronological(order string) bool {
+	return strings.EqualFold(strings.TrimSpace(order), models.SortReverseChronological)
+}
+
+// laterFirst reports whether the range a sorts before the range b in reverse
+// chronological order: the later end date first, then the later start date. A range
+// without an end date ends at its start, and ranges with no readable date sort last.
+func laterFirst(aStart, aEnd, bStart, bEnd string) bool {
+	aEndKey, aStartKey, aOK := chronoKeys(aStart, aEnd)
+	bEndKey, bStartKey, bOK := chronoKeys(bStart, bEnd)
+	switch {
+	case !aOK || !bOK:
+		return aOK && !bOK
+	case aEndKey != bEndKey:
+		return aEndKey > bEndKey
+	default:
+		return aStartKey > bStartKey
+	}
+}
+
+// chronoKeys returns sortable month keys for a date range. Ongoing ranges end after
+// every date, and a year-only date sorts as the last month of its year.
+func chronoKeys(start, end string) (endKey, startKey int, ok bool) {
+	startKey, startOK := chronoMonth(start)
+	endKey, endOK := chronoMonth(end)
+	switch {
+	case endOK && startOK:
+		return endKey, startKey, true
+	case endOK:
+		return endKey, endKey, true
+	case startOK:
+		return startKey, startKey, true
+	default:
+		return 0, 0, false
+	}
+}
+
+func chronoMonth(value string) (int, bool) {
+	date, err := dates.Parse(value)
+	if err != nil {
+		return 0, false
+	}
+	if date.Present {
+		return math.MaxInt, true
+	}
+	_, last := date.Span(time.Time{})
+	return last, true
+}
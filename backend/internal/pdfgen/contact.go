@@ -0,0 +1,120 @@
+package pdfgen
+
+import (
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+)
+
+// Contact icons drawn before contact tokens when settings.contactIcons is set.
+const (
+	iconPhone    = "phone"
+	iconMail     = "mail"
+	iconLinkedIn = "linkedin"
+	iconGitHub   = "github"
+	iconGlobe    = "globe"
+)
+
+// contactIconGap separates an icon from its token text, in millimetres.
+const contactIconGap = 1.2
+
+// contactOptions controls how contact tokens are built from personal info.
+type contactOptions struct {
+	shortenLinks bool
+	icons        bool
+}
+
+func contactOptionsFrom(settings models.ResumeSetting) contactOptions {
+	return contactOptions{shortenLinks: settings.ShortenLinks, icons: settings.ContactIcons}
+}
+
+// contactIconSize returns the icon edge length that matches text set at fontSize.
+func contactIconSize(fontSize float64) float64 {
+	return fontSize * 0.28
+}
+
+// contactTokenWidth is the width of the token text plus its icon, if any.
+func contactTokenWidth(pdf *fpdf.Fpdf, token contactToken, fontSize float64) float64 {
+	width := pdf.GetStringWidth(token.text)
+	if token.icon != "" {
+		width += contactIconSize(fontSize) + contactIconGap
+	}
+	return width
+}
+
+// displayURL shortens a link for display by dropping the scheme, a leading "www." and
+// trailing slashes, so "https://www.linkedin.com/in/foo/" reads "linkedin.com/in/foo".
+func displayURL(value string) string {
+	display := strings.TrimSpace(value)
+	lower := strings.ToLower(display)
+	for _, scheme := range []string{"https://", "http://"} {
+		if strings.HasPrefix(lower, scheme) {
+			display = display[len(scheme):]
+			lower = lower[len(scheme):]
+			break
+		}
+	}
+	if strings.HasPrefix(lower, "www.") {
+		display = display[len("www."):]
+	}
+	return strings.TrimRight(display, "/")
+}
+
+// drawContactIcon draws icon in a size x size box whose top-left corner is (x, y). The
+// font is restored to fontFamily at fontSize afterwards.
+func drawContactIcon(pdf *fpdf.Fpdf, icon string, x float64, y float64, size float64, fontFamily string, fontSize float64, color rgbColor) {
+	setDrawColor(pdf, color)
+	pdf.SetFillColor(color.r, color.g, color.b)
+	pdf.SetLineWidth(size * 0.09)
+
+	cx, cy := x+size/2, y+size/2
+	switch icon {
+	case iconPhone:
+		pdf.RoundedRect(x+size*0.22, y, size*0.56, size, size*0.12, "1234", "D")
+		pdf.Line(x+size*0.4, y+size*0.82, x+size*0.6, y+size*0.82)
+	case iconMail:
+		top, bottom := y+size*0.15, y+size*0.85
+		pdf.Rect(x, top, size, bottom-top, "D")
+		pdf.Line(x, top, cx, y+size*0.55)
+		pdf.Line(cx, y+size*0.55, x+size, top)
+	case iconLinkedIn:
+		pdf.RoundedRect(x, y, size, size, size*0.18, "1234", "F")
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont(fontFamily, "B", fontSize*0.62)
+		pdf.SetXY(x, y)
+		pdf.CellFormat(size, size, "in", "", 0, "C", false, 0, "")
+	case iconGitHub:
+		radius := size * 0.38
+		pdf.Circle(cx, cy+size*0.08, radius, "F")
+		ear := []fpdf.PointType{{X: cx - radius, Y: cy - size*0.05}, {X: cx - radius*0.9, Y: y}, {X: cx - radius*0.25, Y: cy - radius*0.7}}
+		pdf.Polygon(ear, "F")
+		ear = []fpdf.PointType{{X: cx + radius, Y: cy - size*0.05}, {X: cx + radius*0.9, Y: y}, {X: cx + radius*0.25, Y: cy - radius*0.7}}
+		pdf.Polygon(ear, "F")
+	default:
+		radius := size / 2
+		pdf.Circle(cx, cy, radius, "D")
+		pdf.Ellipse(cx, cy, radius*0.45, radius, 0, "D")
+		pdf.Line(x, cy, x+size, cy)
+	}
+
+	setDrawColor(pdf, black)
+	pdf.SetFillColor(255, 255, 255)
+	pdf.SetLineWidth(0.2)
+	pdf.SetFont(fontFamily, "", fontSize)
+}
+
+// drawTokenIcon draws token's icon at the current position, centred on the line, and
+// moves past it. The icon shares the token's link target.
+func drawTokenIcon(pdf *fpdf.Fpdf, token contactToken, fontFamily string, fontSize float64, layout layoutConfig) {
+	size := contactIconSize(fontSize)
+	x, y := pdf.GetXY()
+	top := y + (layout.lineHeight-size)/2
+	drawContactIcon(pdf, token.icon, x, top, size, fontFamily, fontSize, layout.theme.accent)
+	if token.url != "" {
+		pdf.LinkString(x, top, size, size, token.url)
+	}
+	setTextColor(pdf, layout.theme.text)
+	pdf.SetXY(x+size+contactIconGap, y)
+}
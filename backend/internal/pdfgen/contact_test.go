@@ -0,0 +1,64 @@
+package pdfgen
+
+import (
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestDisplayURL(t *testing.T) {
+	cases := map[string]string{
+		"https://www.linkedin.com/in/foo": "linkedin.com/in/foo",
+		"HTTP://WWW.Example.com/":         "Example.com",
+		"github.com/foo//":                "github.com/foo",
+		" www.portfolio.dev/work/ ":       "portfolio.dev/work",
+		"https://example.com/path?q=a/":   "example.com/path?q=a",
+		"wwwexample.com":                  "wwwexample.com",
+	}
+	for input, want := range cases {
+		if got := displayURL(input); got != want {
+			t.Fatalf("displayURL(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestBuildHeaderContactTokensShortensLinksAndAddsIcons(t *testing.T) {
+	info := models.PersonalInfo{
+		Phone:      "+1 555 0100",
+		Email:      "ada@example.com",
+		LinkedIn:   "https://www.linkedin.com/in/ada/",
+		Website:    "http://ada.example",
+		OtherLinks: []models.PersonalLink{{Label: "Notes/", URL: "https://notes.example"}},
+	}
+
+	tokens := buildHeaderContactTokens(info, contactOptions{shortenLinks: true, icons: true})
+	want := []contactToken{
+		{field: "data.personalInfo.phone", text: "+1 555 0100", icon: iconPhone},
+		{field: "data.personalInfo.email", text: "ada@example.com", url: "mailto:ada@example.com", icon: iconMail},
+		{field: "data.personalInfo.linkedin", text: "linkedin.com/in/ada", url: "https://www.linkedin.com/in/ada/", icon: iconLinkedIn},
+		{field: "data.personalInfo.website", text: "ada.example", url: "http://ada.example", icon: iconGlobe},
+		{field: "data.personalInfo.otherLinks[0]", text: "Notes/", url: "https://notes.example", icon: iconGlobe},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("expected %d tokens, got %+v", len(want), tokens)
+	}
+	for i := range want {
+		if tokens[i] != want[i] {
+			t.Fatalf("token %d: expected %+v, got %+v", i, want[i], tokens[i])
+		}
+	}
+
+	plain := buildHeaderContactTokens(info, contactOptions{})
+	if plain[2].text != info.LinkedIn || plain[2].icon != "" {
+		t.Fatalf("expected raw link text without icon by default, got %+v", plain[2])
+	}
+}
+
+func TestContactIconsKeepHeaderHeight(t *testing.T) {
+	req := headerRequest("", false)
+	withoutIcons := firstSectionY(t, req)
+	req.Settings.ContactIcons = true
+	if withIcons := firstSectionY(t, req); withIcons != withoutIcons {
+		t.Fatalf("expected icons to fit on the contact line, got y=%v vs %v", withIcons, withoutIcons)
+	}
+}
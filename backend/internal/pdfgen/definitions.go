@@ -0,0 +1,404 @@
+package pdfgen
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"resume_maker/backend/internal/models"
+)
+
+//go:embed templates/*.json
+var builtinTemplateFiles embed.FS
+
+// templateDefinition is the declarative JSON form of a template. Fields left out of a
+// definition file keep the classic layout values.
+type templateDefinition struct {
+	ID          string                     `json:"id"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Theme       string                     `json:"theme"`
+	BulletStyle string                     `json:"bulletStyle"`
+	Margins     marginDefinition           `json:"margins"`
+	Spacing     spacingDefinition          `json:"spacing"`
+	Columns     columnDefinition           `json:"columns"`
+	Fonts       fontDefinition             `json:"fonts"`
+	Sections    sectionDefinition          `json:"sections"`
+	Pagination  paginationDefinition       `json:"pagination"`
+	Sidebar     *sidebarDefinition         `json:"sidebar"`
+	Rows        map[string][]rowDefinition `json:"rows"`
+}
+
+type marginDefinition struct {
+	Top    float64 `json:"top"`
+	Right  float64 `json:"right"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+}
+
+type spacingDefinition struct {
+	Line    float64 `json:"line"`
+	Section float64 `json:"section"`
+	Entry   float64 `json:"entry"`
+}
+
+type columnDefinition struct {
+	Right      float64 `json:"right"`
+	SkillLabel float64 `json:"skillLabel"`
+}
+
+type fontDefinition struct {
+	NameSizeDelta  float64 `json:"nameSizeDelta"`
+	TitleSizeDelta float64 `json:"titleSizeDelta"`
+	TitleStyle     string  `json:"titleStyle"`
+}
+
+type sectionDefinition struct {
+	Order     []string          `json:"order"`
+	Titles    map[string]string `json:"titles"`
+	TitleCase string            `json:"titleCase"`
+	Divider   string            `json:"divider"`
+}
+
+// paginationDefinition controls how entries are kept together across page breaks.
+type paginationDefinition struct {
+	KeepBullets int `json:"keepBullets"`
+}
+
+// sidebarDefinition switches a template to the two-column sidebar flow.
+type sidebarDefinition struct {
+	Width    float64  `json:"width"`
+	Gutter   float64  `json:"gutter"`
+	Sections []string `json:"sections"`
+}
+
+type rowDefinition struct {
+	Left  string `json:"left"`
+	Right string `json:"right,omitempty"`
+	Style string `json:"style,omitempty"`
+}
+
+// rowFields lists the entry fields a row definition may reference, per section.
+var rowFields = map[string][]string{
+	models.SectionEducation:  {"institution", "location", "degree", "dates", "honors", "coursework"},
+	models.SectionExperience: {"role", "company", "location", "dates"},
+	models.SectionProjects:   {"name", "techStack", "dates"},
+	models.SectionCustom:     {"primary", "secondary", "date", "location"},
+}
+
+// LoadTemplateDir registers every *.json template definition found in dir. Other files,
+// such as YAML definitions, are rejected rather than skipped; subdirectories and hidden
+// files are ignored.
+func LoadTemplateDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("list template definitions: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if !strings.EqualFold(filepath.Ext(name), ".json") {
+			return fmt.Errorf("template definition %s: only .json definitions are supported", filepath.Join(dir, name))
+		}
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		raw, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return fmt.Errorf("read template definition %s: %w", path, readErr)
+		}
+		if loadErr := loadTemplateDefinition(raw); loadErr != nil {
+			return fmt.Errorf("load template definition %s: %w", path, loadErr)
+		}
+	}
+
+	return nil
+}
+
+func loadBuiltinTemplates() error {
+	paths, err := fs.Glob(builtinTemplateFiles, "templates/*.json")
+	if err != nil {
+		return fmt.Errorf("glob builtin templates: %w", err)
+	}
+	// The default template is registered first so it leads the /templates listing.
+	sort.Strings(paths)
+	defaultPath := "templates/" + DefaultTemplateID + ".json"
+	sort.SliceStable(paths, func(i, j int) bool {
+		return paths[i] == defaultPath && paths[j] != defaultPath
+	})
+
+	for _, path := range paths {
+		raw, readErr := builtinTemplateFiles.ReadFile(path)
+		if readErr != nil {
+			return fmt.Errorf("read builtin template %s: %w", path, readErr)
+		}
+		if loadErr := loadTemplateDefinition(raw); loadErr != nil {
+			return fmt.Errorf("load builtin template %s: %w", path, loadErr)
+		}
+	}
+
+	return nil
+}
+
+func loadTemplateDefinition(raw []byte) error {
+	definition := newTemplateDefinition()
+	if err := decodeTemplateDefinition(raw, &definition); err != nil {
+		return err
+	}
+
+	layout, err := definition.layout()
+	if err != nil {
+		return err
+	}
+
+	render := renderSingleColumn
+	if definition.Sidebar != nil {
+		render = renderSidebar
+	}
+
+	return registerTemplate(Template{
+		ID:          definition.ID,
+		Name:        definition.Name,
+		Description: definition.Description,
+		layout:      layout,
+		render:      render,
+	})
+}
+
+func decodeTemplateDefinition(raw []byte, definition *templateDefinition) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(definition); err != nil {
+		return fmt.Errorf("decode definition: %w", err)
+	}
+	return nil
+}
+
+// newTemplateDefinition returns a definition pre-filled with the classic layout values.
+func newTemplateDefinition() templateDefinition {
+	layout := defaultLayout()
+
+	rows := make(map[string][]rowDefinition, len(layout.entryRows))
+	for section, specs := range layout.entryRows {
+		defs := make([]rowDefinition, 0, len(specs))
+		for _, spec := range specs {
+			defs = append(defs, rowDefinition{Left: spec.left, Right: spec.right, Style: spec.style})
+		}
+		rows[section] = defs
+	}
+
+	titles := make(map[string]string, len(layout.sectionTitles))
+	for section, title := range layout.sectionTitles {
+		titles[section] = title
+	}
+
+	return templateDefinition{
+		Theme:       models.ThemeClassic,
+		BulletStyle: layout.bulletStyle,
+		Margins: marginDefinition{
+			Top:    layout.topMargin,
+			Right:  layout.rightMargin,
+			Bottom: layout.bottomMargin,
+			Left:   layout.leftMargin,
+		},
+		Spacing: spacingDefinition{
+			Line:    layout.lineHeight,
+			Section: layout.sectionSpacing,
+			Entry:   layout.entrySpacing,
+		},
+		Columns: columnDefinition{
+			Right:      layout.rightColWidth,
+			SkillLabel: layout.skillLabelW,
+		},
+		Fonts: fontDefinition{
+			NameSizeDelta:  layout.nameSizeDelta,
+			TitleSizeDelta: layout.titleSizeDelta,
+			TitleStyle:     layout.titleStyle,
+		},
+		Sections: sectionDefinition{
+			Order:     append([]string(nil), layout.sectionOrder...),
+			Titles:    titles,
+			TitleCase: layout.titleCase,
+			Divider:   layout.divider,
+		},
+		Pagination: paginationDefinition{
+			KeepBullets: layout.keepBullets,
+		},
+		Rows: rows,
+	}
+}
+
+func (d templateDefinition) layout() (layoutConfig, error) {
+	id := normalizeTemplateID(d.ID)
+	if id == "" {
+		return layoutConfig{}, fmt.Errorf("id must not be empty")
+	}
+	if d.Name == "" {
+		return layoutConfig{}, fmt.Errorf("template %s: name must not be empty", id)
+	}
+
+	margins := []float64{d.Margins.Top, d.Margins.Right, d.Margins.Bottom, d.Margins.Left}
+	for _, margin := range margins {
+		if margin < 0 || margin > 50 {
+			return layoutConfig{}, fmt.Errorf("template %s: margins must be between 0 and 50mm", id)
+		}
+	}
+	if d.Spacing.Line <= 0 {
+		return layoutConfig{}, fmt.Errorf("template %s: spacing.line must be positive", id)
+	}
+	if d.Spacing.Section < 0 || d.Spacing.Entry < 0 {
+		return layoutConfig{}, fmt.Errorf("template %s: spacing.section and spacing.entry must not be negative", id)
+	}
+	if d.Columns.Right <= 0 || d.Columns.SkillLabel <= 0 {
+		return layoutConfig{}, fmt.Errorf("template %s: column widths must be positive", id)
+	}
+	if !isValidFontStyle(d.Fonts.TitleStyle) {
+		return layoutConfig{}, fmt.Errorf("template %s: fonts.titleStyle must be one of \"\", B, I, BI", id)
+	}
+
+	theme, ok := themePresets[d.Theme]
+	if !ok {
+		return layoutConfig{}, fmt.Errorf("template %s: theme must be one of %v", id, models.ThemePresets)
+	}
+	if _, ok := bulletGlyphs[d.BulletStyle]; !ok {
+		return layoutConfig{}, fmt.Errorf("template %s: bulletStyle must be one of %v", id, models.BulletStyles)
+	}
+	if d.Pagination.KeepBullets < 0 || d.Pagination.KeepBullets > 10 {
+		return layoutConfig{}, fmt.Errorf("template %s: pagination.keepBullets must be between 0 and 10", id)
+	}
+
+	switch d.Sections.TitleCase {
+	case titleCaseUpper, titleCaseTitle, titleCaseNone:
+	default:
+		return layoutConfig{}, fmt.Errorf("template %s: sections.titleCase must be one of upper, title, none", id)
+	}
+	switch d.Sections.Divider {
+	case dividerLine, dividerThick, dividerNone:
+	default:
+		return layoutConfig{}, fmt.Errorf("template %s: sections.divider must be one of line, thick, none", id)
+	}
+
+	seen := make(map[string]bool, len(d.Sections.Order))
+	for _, section := range d.Sections.Order {
+		if !models.IsSectionKey(section) {
+			return layoutConfig{}, fmt.Errorf("template %s: unknown section %q in sections.order", id, section)
+		}
+		if seen[section] {
+			return layoutConfig{}, fmt.Errorf("template %s: section %q listed twice in sections.order", id, section)
+		}
+		seen[section] = true
+	}
+	for section := range d.Sections.Titles {
+		if !models.IsSectionKey(section) {
+			return layoutConfig{}, fmt.Errorf("template %s: unknown section %q in sections.titles", id, section)
+		}
+	}
+
+	sidebar, err := d.sidebarLayout(id)
+	if err != nil {
+		return layoutConfig{}, err
+	}
+
+	entryRows := make(map[string][]rowSpec, len(d.Rows))
+	for section, rows := range d.Rows {
+		fields, ok := rowFields[section]
+		if !ok {
+			return layoutConfig{}, fmt.Errorf("template %s: section %q does not support row composition", id, section)
+		}
+		if len(rows) == 0 {
+			return layoutConfig{}, fmt.Errorf("template %s: rows.%s must contain at least one row", id, section)
+		}
+		specs := make([]rowSpec, 0, len(rows))
+		for index, row := range rows {
+			if !containsString(fields, row.Left) {
+				return layoutConfig{}, fmt.Errorf("template %s: rows.%s[%d].left must be one of %v", id, section, index, fields)
+			}
+			if row.Right != "" && !containsString(fields, row.Right) {
+				return layoutConfig{}, fmt.Errorf("template %s: rows.%s[%d].right must be one of %v", id, section, index, fields)
+			}
+			if !isValidFontStyle(row.Style) {
+				return layoutConfig{}, fmt.Errorf("template %s: rows.%s[%d].style must be one of \"\", B, I, BI", id, section, index)
+			}
+			specs = append(specs, rowSpec{left: row.Left, right: row.Right, style: row.Style})
+		}
+		entryRows[section] = specs
+	}
+
+	return layoutConfig{
+		page:           pageSizes[models.PageSizeA4],
+		theme:          theme,
+		leftMargin:     d.Margins.Left,
+		rightMargin:    d.Margins.Right,
+		topMargin:      d.Margins.Top,
+		bottomMargin:   d.Margins.Bottom,
+		lineHeight:     d.Spacing.Line,
+		sectionSpacing: d.Spacing.Section,
+		entrySpacing:   d.Spacing.Entry,
+		rightColWidth:  d.Columns.Right,
+		skillLabelW:    d.Columns.SkillLabel,
+		keepBullets:    d.Pagination.KeepBullets,
+		bulletStyle:    d.BulletStyle,
+		nameSizeDelta:  d.Fonts.NameSizeDelta,
+		titleSizeDelta: d.Fonts.TitleSizeDelta,
+		titleStyle:     d.Fonts.TitleStyle,
+		titleCase:      d.Sections.TitleCase,
+		divider:        d.Sections.Divider,
+		sectionOrder:   d.Sections.Order,
+		sectionTitles:  d.Sections.Titles,
+		entryRows:      entryRows,
+		sidebar:        sidebar,
+	}, nil
+}
+
+func (d templateDefinition) sidebarLayout(id string) (sidebarLayout, error) {
+	if d.Sidebar == nil {
+		return sidebarLayout{}, nil
+	}
+	if d.Sidebar.Width < 30 || d.Sidebar.Width > 100 {
+		return sidebarLayout{}, fmt.Errorf("template %s: sidebar.width must be between 30 and 100mm", id)
+	}
+	if d.Sidebar.Gutter < 0 || d.Sidebar.Gutter > 20 {
+		return sidebarLayout{}, fmt.Errorf("template %s: sidebar.gutter must be between 0 and 20mm", id)
+	}
+	seen := make(map[string]bool, len(d.Sidebar.Sections))
+	for _, section := range d.Sidebar.Sections {
+		if !models.IsSectionKey(section) {
+			return sidebarLayout{}, fmt.Errorf("template %s: unknown section %q in sidebar.sections", id, section)
+		}
+		if seen[section] {
+			return sidebarLayout{}, fmt.Errorf("template %s: section %q listed twice in sidebar.sections", id, section)
+		}
+		seen[section] = true
+	}
+	return sidebarLayout{width: d.Sidebar.Width, gutter: d.Sidebar.Gutter, sections: d.Sidebar.Sections}, nil
+}
+
+func isValidFontStyle(style string) bool {
+	switch style {
+	case "", "B", "I", "BI":
+		return true
+	default:
+		return false
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
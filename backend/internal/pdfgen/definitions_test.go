@@ -0,0 +1,134 @@
+package pdfgen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestClassicDefinitionMatchesDefaultLayout(t *testing.T) {
+	raw, err := builtinTemplateFiles.ReadFile("templates/classic.json")
+	if err != nil {
+		t.Fatalf("read classic definition: %v", err)
+	}
+
+	definition := newTemplateDefinition()
+	if err := decodeTemplateDefinition(raw, &definition); err != nil {
+		t.Fatalf("decode classic definition: %v", err)
+	}
+	layout, err := definition.layout()
+	if err != nil {
+		t.Fatalf("build classic layout: %v", err)
+	}
+
+	if !reflect.DeepEqual(layout, defaultLayout()) {
+		t.Fatalf("expected classic.json to describe defaultLayout()\nactual=%+v\nexpected=%+v", layout, defaultLayout())
+	}
+}
+
+func TestLoadTemplateDirRegistersDefinitions(t *testing.T) {
+	dir := t.TempDir()
+	writeDefinition(t, dir, "minimal-serif.json", `{
+		"id": "minimal-serif",
+		"name": "Minimal Serif",
+		"description": "Experience-first layout without section rules.",
+		"spacing": { "line": 6 },
+		"sections": {
+			"order": ["experience", "technicalSkills"],
+			"titleCase": "none",
+			"divider": "none"
+		},
+		"rows": {
+			"experience": [{ "left": "company", "right": "dates", "style": "BI" }]
+		}
+	}`)
+	t.Cleanup(func() { unregisterTemplate("minimal-serif") })
+
+	if err := LoadTemplateDir(dir); err != nil {
+		t.Fatalf("load template dir: %v", err)
+	}
+
+	template, ok := LookupTemplate("minimal-serif")
+	if !ok {
+		t.Fatal("expected loaded template to be registered")
+	}
+	if template.Name != "Minimal Serif" {
+		t.Fatalf("expected loaded template name, got %q", template.Name)
+	}
+
+	pdfBytes, err := Generator{}.Generate(models.GeneratePDFRequest{
+		TemplateID: "minimal-serif",
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Experience:   []models.ExperienceEntry{{Role: "Analyst", Company: "Engines Ltd"}},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium"},
+	})
+	if err != nil {
+		t.Fatalf("generate with loaded template: %v", err)
+	}
+	if !strings.HasPrefix(string(pdfBytes), "%PDF") {
+		t.Fatal("expected loaded template to render a PDF")
+	}
+}
+
+func TestLoadTemplateDirRejectsUnsupportedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeDefinition(t, dir, "minimal-serif.yaml", "id: minimal-serif\nname: Minimal Serif\n")
+
+	err := LoadTemplateDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "minimal-serif.yaml") {
+		t.Fatalf("expected the YAML definition to be rejected, got %v", err)
+	}
+}
+
+func TestLoadTemplateDefinitionRejectsInvalidDefinitions(t *testing.T) {
+	cases := map[string]string{
+		"missing id":        `{"name": "No ID"}`,
+		"unknown field":     `{"id": "x-unknown-field", "name": "X", "colour": "red"}`,
+		"unknown section":   `{"id": "x-section", "name": "X", "sections": {"order": ["hobbies"]}}`,
+		"duplicate section": `{"id": "x-dup", "name": "X", "sections": {"order": ["projects", "projects"]}}`,
+		"bad title case":    `{"id": "x-case", "name": "X", "sections": {"titleCase": "shout"}}`,
+		"bad divider":       `{"id": "x-divider", "name": "X", "sections": {"divider": "dotted"}}`,
+		"bad row field":     `{"id": "x-row", "name": "X", "rows": {"experience": [{"left": "salary"}]}}`,
+		"bad row style":     `{"id": "x-style", "name": "X", "rows": {"projects": [{"left": "name", "style": "U"}]}}`,
+		"negative spacing":  `{"id": "x-spacing", "name": "X", "spacing": {"entry": -1}}`,
+		"negative keep":     `{"id": "x-keep", "name": "X", "pagination": {"keepBullets": -1}}`,
+		"unknown theme":     `{"id": "x-theme", "name": "X", "theme": "neon"}`,
+		"unknown bullet":    `{"id": "x-bullet", "name": "X", "bulletStyle": "star"}`,
+		"narrow sidebar":    `{"id": "x-sidebar", "name": "X", "sidebar": {"width": 10}}`,
+		"sidebar section":   `{"id": "x-sidebar-section", "name": "X", "sidebar": {"width": 50, "sections": ["hobbies"]}}`,
+		"duplicate id":      `{"id": "classic", "name": "Classic Again"}`,
+	}
+
+	for name, raw := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := loadTemplateDefinition([]byte(raw)); err == nil {
+				t.Fatalf("expected definition to be rejected: %s", raw)
+			}
+		})
+	}
+}
+
+func writeDefinition(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("write definition %s: %v", name, err)
+	}
+}
+
+func unregisterTemplate(id string) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+
+	for index, template := range templates {
+		if template.ID == id {
+			templates = append(templates[:index], templates[index+1:]...)
+			return
+		}
+	}
+}
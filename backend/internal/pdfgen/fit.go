@@ -0,0 +1,126 @@
+package pdfgen
+
+import (
+	"fmt"
+	"math"
+
+	"resume_maker/backend/internal/models"
+)
+
+// Fit steps tighten the layout a little at a time and never past these bounds.
+const (
+	maxFitSteps       = 6
+	minFitFontSize    = 9.0
+	minFitLineScale   = 0.82
+	minFitSpaceScale  = 0.25
+	minFitMargin      = 10.0
+	fitMarginStep     = 1.5
+	fitFontSizeStep   = 0.5
+	fitLineScaleStep  = 0.03
+	fitSpaceScaleStep = 0.15
+)
+
+// FitError reports that a resume could not be fitted to the requested page count.
+type FitError struct {
+	TargetPages int
+	Pages       int
+}
+
+func (e *FitError) Error() string {
+	return fmt.Sprintf("resume needs %d pages at the tightest layout, target is %d", e.Pages, e.TargetPages)
+}
+
+// renderFitted renders req with the template layout adjusted for the requested paper,
+// margins, and theme and, when settings.fitToPages is set, re-runs the layout with progressively
+// tighter values until the page count fits.
+func renderFitted(template Template, req models.GeneratePDFRequest) (*renderedDocument, *models.FitReport, error) {
+	base := applyBulletSettings(applyThemeSettings(applyPageSettings(template.layout, req.Settings), req.Settings), req.Settings)
+	doc, err := renderTemplate(template, req, base)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target := req.Settings.FitToPages
+	if target <= 0 {
+		return doc, nil, nil
+	}
+	if doc.pdf.PageCount() <= target {
+		return doc, &models.FitReport{TargetPages: target, Pages: doc.pdf.PageCount(), Adjustments: []models.FitAdjustment{}}, nil
+	}
+
+	baseFontSize := mapFontSize(req.Settings.FontSize)
+	for step := 1; step <= maxFitSteps; step++ {
+		layout := fitLayout(base, baseFontSize, step)
+		doc, err = renderTemplate(template, req, layout)
+		if err != nil {
+			return nil, nil, err
+		}
+		if doc.pdf.PageCount() <= target {
+			return doc, &models.FitReport{
+				TargetPages: target,
+				Pages:       doc.pdf.PageCount(),
+				Adjustments: fitAdjustments(base, layout, baseFontSize),
+			}, nil
+		}
+	}
+
+	return nil, nil, &FitError{TargetPages: target, Pages: doc.pdf.PageCount()}
+}
+
+// fitLayout returns base tightened by step increments, clamped to the safe bounds.
+func fitLayout(base layoutConfig, baseFontSize float64, step int) layoutConfig {
+	layout := base
+	factor := float64(step)
+
+	layout.fontSizeOffset = math.Max(base.fontSizeOffset-fitFontSizeStep*factor, minFitFontSize-baseFontSize)
+	if layout.fontSizeOffset > base.fontSizeOffset {
+		layout.fontSizeOffset = base.fontSizeOffset
+	}
+
+	lineScale := math.Max(1-fitLineScaleStep*factor, minFitLineScale)
+	spaceScale := math.Max(1-fitSpaceScaleStep*factor, minFitSpaceScale)
+	layout.lineHeight = base.lineHeight * lineScale
+	layout.sectionSpacing = base.sectionSpacing * spaceScale
+	layout.entrySpacing = base.entrySpacing * spaceScale
+
+	layout.topMargin = shrinkMargin(base.topMargin, factor)
+	layout.rightMargin = shrinkMargin(base.rightMargin, factor)
+	layout.bottomMargin = shrinkMargin(base.bottomMargin, factor)
+	layout.leftMargin = shrinkMargin(base.leftMargin, factor)
+
+	return layout
+}
+
+func shrinkMargin(margin float64, factor float64) float64 {
+	if margin <= minFitMargin {
+		return margin
+	}
+	return math.Max(margin-fitMarginStep*factor, minFitMargin)
+}
+
+func fitAdjustments(base layoutConfig, fitted layoutConfig, baseFontSize float64) []models.FitAdjustment {
+	candidates := []models.FitAdjustment{
+		{Property: "fontSize", From: baseFontSize + base.fontSizeOffset, To: baseFontSize + fitted.fontSizeOffset},
+		{Property: "lineHeight", From: base.lineHeight, To: fitted.lineHeight},
+		{Property: "sectionSpacing", From: base.sectionSpacing, To: fitted.sectionSpacing},
+		{Property: "entrySpacing", From: base.entrySpacing, To: fitted.entrySpacing},
+		{Property: "marginTop", From: base.topMargin, To: fitted.topMargin},
+		{Property: "marginRight", From: base.rightMargin, To: fitted.rightMargin},
+		{Property: "marginBottom", From: base.bottomMargin, To: fitted.bottomMargin},
+		{Property: "marginLeft", From: base.leftMargin, To: fitted.leftMargin},
+	}
+
+	adjustments := make([]models.FitAdjustment, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidate.From = roundHundredths(candidate.From)
+		candidate.To = roundHundredths(candidate.To)
+		if candidate.From != candidate.To {
+			adjustments = append(adjustments, candidate)
+		}
+	}
+	return adjustments
+}
+
+func roundHundredths(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,100 @@
+package pdfgen
+
+import (
+	"errors"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestRenderFitsSlightOverflowOnOnePage(t *testing.T) {
+	req := fitRequest(44)
+
+	unfitted, err := Generator{}.Render(req)
+	if err != nil {
+		t.Fatalf("render without fit: %v", err)
+	}
+	if unfitted.Pages < 2 {
+		t.Fatalf("expected fixture to overflow onto a second page, got %d page(s)", unfitted.Pages)
+	}
+	if unfitted.Fit != nil {
+		t.Fatal("expected no fit report when fitToPages is unset")
+	}
+
+	req.Settings.FitToPages = 1
+	fitted, err := Generator{}.Render(req)
+	if err != nil {
+		t.Fatalf("render with fit: %v", err)
+	}
+	if fitted.Pages != 1 {
+		t.Fatalf("expected fitted resume on 1 page, got %d", fitted.Pages)
+	}
+	if fitted.Fit == nil || len(fitted.Fit.Adjustments) == 0 {
+		t.Fatalf("expected fit report with adjustments, got %+v", fitted.Fit)
+	}
+	for _, adjustment := range fitted.Fit.Adjustments {
+		if adjustment.To >= adjustment.From {
+			t.Fatalf("expected %s to be tightened, got %v -> %v", adjustment.Property, adjustment.From, adjustment.To)
+		}
+	}
+}
+
+func TestRenderReportsNoAdjustmentsWhenAlreadyFitting(t *testing.T) {
+	req := fitRequest(3)
+	req.Settings.FitToPages = 1
+
+	result, err := Generator{}.Render(req)
+	if err != nil {
+		t.Fatalf("render with fit: %v", err)
+	}
+	if result.Fit == nil || result.Fit.Pages != 1 || len(result.Fit.Adjustments) != 0 {
+		t.Fatalf("expected untouched one-page fit report, got %+v", result.Fit)
+	}
+}
+
+func TestRenderReturnsFitErrorWhenContentCannotFit(t *testing.T) {
+	req := fitRequest(120)
+	req.Settings.FitToPages = 1
+
+	_, err := Generator{}.Render(req)
+	var fitErr *FitError
+	if !errors.As(err, &fitErr) {
+		t.Fatalf("expected FitError, got %v", err)
+	}
+	if fitErr.TargetPages != 1 || fitErr.Pages < 2 {
+		t.Fatalf("expected target 1 and at least 2 pages, got %+v", fitErr)
+	}
+}
+
+func TestFitLayoutStaysWithinSafeBounds(t *testing.T) {
+	base := defaultLayout()
+	layout := fitLayout(base, mapFontSize("small"), maxFitSteps)
+
+	if fontSize := mapFontSize("small") + layout.fontSizeOffset; fontSize < minFitFontSize {
+		t.Fatalf("expected font size >= %v, got %v", minFitFontSize, fontSize)
+	}
+	if layout.lineHeight < base.lineHeight*minFitLineScale {
+		t.Fatalf("expected line height >= %v, got %v", base.lineHeight*minFitLineScale, layout.lineHeight)
+	}
+	for _, margin := range []float64{layout.topMargin, layout.rightMargin, layout.bottomMargin, layout.leftMargin} {
+		if margin < minFitMargin {
+			t.Fatalf("expected margins >= %v, got %v", minFitMargin, margin)
+		}
+	}
+}
+
+func fitRequest(bulletCount int) models.GeneratePDFRequest {
+	bullets := make([]models.Bullet, 0, bulletCount)
+	for i := 0; i < bulletCount; i++ {
+		bullets = append(bullets, models.Bullet{Text: "Implemented concurrency-heavy backend systems and validated throughput under sustained load."})
+	}
+	return models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"},
+			Experience: []models.ExperienceEntry{
+				{Role: "Backend Engineer", Company: "Engines Ltd", StartDate: "2020", EndDate: "2024", Bullets: bullets},
+			},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium"},
+	}
+}
@@ -0,0 +1,72 @@
+package pdfgen
+
+import (
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+const testPhotoDataURL = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mP8/x8AAwMCAO7YhJkAAAAASUVORK5CYII="
+
+func headerRequest(headerLayout string, showPhoto bool) models.GeneratePDFRequest {
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{
+				FirstName: "Barbara",
+				LastName:  "Liskov",
+				Email:     "barbara@example.com",
+				Phone:     "+1 555 0142",
+				GitHub:    "github.com/liskov",
+			},
+			Projects: []models.ProjectEntry{{Name: "CLU"}},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium", HeaderLayout: headerLayout, ShowPhoto: showPhoto},
+	}
+	if showPhoto {
+		req.Photo = testPhotoDataURL
+	}
+	return req
+}
+
+func firstSectionY(t *testing.T, req models.GeneratePDFRequest) float64 {
+	t.Helper()
+	report, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	return report.Sections[0].Y
+}
+
+func TestSplitHeaderPlacesContactsBesideName(t *testing.T) {
+	centered := firstSectionY(t, headerRequest("", false))
+	left := firstSectionY(t, headerRequest(models.HeaderLeft, false))
+	split := firstSectionY(t, headerRequest(models.HeaderSplit, false))
+
+	if left != centered {
+		t.Fatalf("expected left-aligned header to keep the centered height, got %v vs %v", left, centered)
+	}
+	if split >= centered {
+		t.Fatalf("expected split header to be shorter than centered, got %v vs %v", split, centered)
+	}
+}
+
+func TestPhotoLeftHeaderClearsThePhoto(t *testing.T) {
+	layout := defaultLayout()
+	if y := firstSectionY(t, headerRequest(models.HeaderPhotoLeft, true)); y < layout.topMargin+headerPhotoDiameter {
+		t.Fatalf("expected first section below the photo, got y=%v", y)
+	}
+}
+
+func TestNormalizeHeaderLayout(t *testing.T) {
+	cases := map[string]string{
+		"":          models.HeaderCentered,
+		" Split ":   models.HeaderSplit,
+		"photoleft": models.HeaderPhotoLeft,
+		"diagonal":  models.HeaderCentered,
+	}
+	for input, want := range cases {
+		if got := normalizeHeaderLayout(input); got != want {
+			t.Fatalf("normalizeHeaderLayout(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
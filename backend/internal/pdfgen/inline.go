@@ -0,0 +1,218 @@
+package pdfgen
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/richtext"
+)
+
+// inlineFragment is a run of text on one wrapped line drawn in a single style.
+type inlineFragment struct {
+	text  string
+	style string
+	url   string
+	width float64
+}
+
+// inlineWord is a whitespace-delimited word, which may mix several styles.
+type inlineWord struct {
+	fragments   []inlineFragment
+	spaceBefore bool
+}
+
+// parseInlineText returns the styled spans of value and whether any span carries a
+// style or link. Text that fails to parse is treated as plain.
+func parseInlineText(value string) ([]richtext.Span, bool) {
+	if !richtext.HasMarkup(value) {
+		return []richtext.Span{{Text: value}}, false
+	}
+	spans, err := richtext.Parse(value)
+	if err != nil {
+		return []richtext.Span{{Text: value}}, false
+	}
+	for _, span := range spans {
+		if span.Bold || span.Italic || span.URL != "" {
+			return spans, true
+		}
+	}
+	return []richtext.Span{{Text: richtext.Plain(value)}}, false
+}
+
+// writeInlineText wraps spans across the content width, switching fonts and link
+// targets within a line as needed.
+func writeInlineText(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, spans []richtext.Span, layout layoutConfig) {
+	contentWidth := layout.contentWidth()
+
+	for _, line := range layoutInlineText(pdf, fontFamily, fontSize, spans, contentWidth) {
+		ensureSpace(pdf, layout.lineHeight, layout)
+		pdf.SetX(layout.leftMargin)
+		drawInlineLine(pdf, fontFamily, fontSize, line, layout)
+		pdf.Ln(layout.lineHeight)
+	}
+}
+
+// drawInlineLine draws one line from layoutInlineText at the cursor, in the link color
+// where a fragment carries a link.
+func drawInlineLine(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, line []inlineFragment, layout layoutConfig) {
+	for _, fragment := range line {
+		pdf.SetFont(fontFamily, fragment.style, fontSize)
+		if fragment.url != "" {
+			setTextColor(pdf, layout.theme.link)
+			pdf.CellFormat(fragment.width, layout.lineHeight, fragment.text, "", 0, "L", false, 0, normalizeLinkURL(fragment.url))
+			setTextColor(pdf, layout.theme.text)
+		} else {
+			pdf.CellFormat(fragment.width, layout.lineHeight, fragment.text, "", 0, "L", false, 0, "")
+		}
+	}
+}
+
+// layoutInlineText breaks spans into lines no wider than width. Words wider than a
+// whole line are split between characters.
+func layoutInlineText(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, spans []richtext.Span, width float64) [][]inlineFragment {
+	words := tokenizeInlineText(spans)
+	for i := range words {
+		for j := range words[i].fragments {
+			fragment := &words[i].fragments[j]
+			pdf.SetFont(fontFamily, fragment.style, fontSize)
+			fragment.width = pdf.GetStringWidth(fragment.text)
+		}
+	}
+
+	pdf.SetFont(fontFamily, "", fontSize)
+	spaceWidth := pdf.GetStringWidth(" ")
+
+	lines := make([][]inlineFragment, 0, 2)
+	var line []inlineFragment
+	lineWidth := 0.0
+	for _, word := range words {
+		for _, piece := range splitInlineWord(pdf, fontFamily, fontSize, word.fragments, width) {
+			pieceWidth := fragmentsWidth(piece)
+			gap := 0.0
+			if len(line) > 0 && word.spaceBefore {
+				gap = spaceWidth
+			}
+			if len(line) > 0 && lineWidth+gap+pieceWidth > width {
+				lines = append(lines, mergeInlineFragments(line))
+				line, lineWidth, gap = nil, 0, 0
+			}
+			if gap > 0 {
+				line = append(line, inlineSpace(line[len(line)-1], piece[0], spaceWidth))
+			}
+			line = append(line, piece...)
+			lineWidth += gap + pieceWidth
+			// Only the first piece of a split word follows a space.
+			word.spaceBefore = false
+		}
+	}
+	if len(line) > 0 {
+		lines = append(lines, mergeInlineFragments(line))
+	}
+	return lines
+}
+
+func tokenizeInlineText(spans []richtext.Span) []inlineWord {
+	words := make([]inlineWord, 0, 16)
+	var current *inlineWord
+	spaceBefore := false
+
+	for _, span := range spans {
+		style := inlineStyle(span)
+		for _, r := range span.Text {
+			if unicode.IsSpace(r) {
+				if current != nil {
+					words = append(words, *current)
+					current = nil
+				}
+				spaceBefore = true
+				continue
+			}
+			if current == nil {
+				current = &inlineWord{spaceBefore: spaceBefore && len(words) > 0}
+				spaceBefore = false
+			}
+			last := len(current.fragments) - 1
+			if last >= 0 && current.fragments[last].style == style && current.fragments[last].url == span.URL {
+				current.fragments[last].text += string(r)
+				continue
+			}
+			current.fragments = append(current.fragments, inlineFragment{text: string(r), style: style, url: span.URL})
+		}
+	}
+	if current != nil {
+		words = append(words, *current)
+	}
+	return words
+}
+
+// splitInlineWord returns fragments unchanged when they fit in width, otherwise splits
+// them into pieces that each fit.
+func splitInlineWord(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, fragments []inlineFragment, width float64) [][]inlineFragment {
+	if fragmentsWidth(fragments) <= width {
+		return [][]inlineFragment{fragments}
+	}
+
+	pieces := make([][]inlineFragment, 0, 2)
+	var piece []inlineFragment
+	pieceWidth := 0.0
+	for _, fragment := range fragments {
+		pdf.SetFont(fontFamily, fragment.style, fontSize)
+		for _, r := range fragment.text {
+			runeWidth := pdf.GetStringWidth(string(r))
+			if len(piece) > 0 && pieceWidth+runeWidth > width {
+				pieces = append(pieces, mergeInlineFragments(piece))
+				piece, pieceWidth = nil, 0
+			}
+			piece = append(piece, inlineFragment{text: string(r), style: fragment.style, url: fragment.url, width: runeWidth})
+			pieceWidth += runeWidth
+		}
+	}
+	if len(piece) > 0 {
+		pieces = append(pieces, mergeInlineFragments(piece))
+	}
+	return pieces
+}
+
+// inlineSpace returns the space between two words, carrying their style and link when
+// both words share them so a multi-word link stays one continuous target.
+func inlineSpace(before inlineFragment, after inlineFragment, width float64) inlineFragment {
+	if before.style == after.style && before.url == after.url {
+		return inlineFragment{text: " ", style: before.style, url: before.url, width: width}
+	}
+	return inlineFragment{text: " ", width: width}
+}
+
+func mergeInlineFragments(fragments []inlineFragment) []inlineFragment {
+	merged := make([]inlineFragment, 0, len(fragments))
+	for _, fragment := range fragments {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].style == fragment.style && merged[last].url == fragment.url {
+			merged[last].text += fragment.text
+			merged[last].width += fragment.width
+			continue
+		}
+		merged = append(merged, fragment)
+	}
+	return merged
+}
+
+func fragmentsWidth(fragments []inlineFragment) float64 {
+	width := 0.0
+	for _, fragment := range fragments {
+		width += fragment.width
+	}
+	return width
+}
+
+func inlineStyle(span richtext.Span) string {
+	var style strings.Builder
+	if span.Bold {
+		style.WriteString("B")
+	}
+	if span.Italic {
+		style.WriteString("I")
+	}
+	return style.String()
+}
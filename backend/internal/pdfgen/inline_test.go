@@ -0,0 +1,74 @@
+package pdfgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/richtext"
+)
+
+func newInlinePDF(t *testing.T) *fpdf.Fpdf {
+	t.Helper()
+	pdf := fpdf.New("P", "mm", "A4", "")
+	if err := registerResumeFonts(pdf); err != nil {
+		t.Fatalf("register fonts: %v", err)
+	}
+	pdf.AddPage()
+	return pdf
+}
+
+func TestLayoutInlineTextMixesStylesOnOneLine(t *testing.T) {
+	pdf := newInlinePDF(t)
+	spans, styled := parseInlineText("Cut latency by **40%**, see [the write-up](example.com/post).")
+	if !styled {
+		t.Fatal("expected markup to be detected")
+	}
+
+	lines := layoutInlineText(pdf, fontFamilyTimes, 11, spans, 170)
+	if len(lines) != 1 {
+		t.Fatalf("expected a single line, got %d", len(lines))
+	}
+
+	var styles, urls []string
+	for _, fragment := range lines[0] {
+		styles = append(styles, fragment.style)
+		urls = append(urls, fragment.url)
+	}
+	if strings.Join(styles, ",") != ",B,,," {
+		t.Fatalf("unexpected fragment styles %q", styles)
+	}
+	if urls[3] != "example.com/post" || lines[0][3].text != "the write-up" {
+		t.Fatalf("expected link fragment to cover its whole text, got %+v", lines[0])
+	}
+}
+
+func TestLayoutInlineTextWrapsWithinWidth(t *testing.T) {
+	pdf := newInlinePDF(t)
+	text := strings.Repeat("Improved **throughput** and *reliability* across services. ", 6) + strings.Repeat("x", 120)
+	spans, _ := parseInlineText(text)
+
+	const width = 80.0
+	lines := layoutInlineText(pdf, fontFamilyTimes, 11, spans, width)
+	if len(lines) < 4 {
+		t.Fatalf("expected text to wrap over several lines, got %d", len(lines))
+	}
+	for index, line := range lines {
+		if lineWidth := fragmentsWidth(line); lineWidth > width+0.01 {
+			t.Fatalf("line %d is %.2fmm wide, exceeding %.2fmm", index, lineWidth, width)
+		}
+	}
+}
+
+func TestParseInlineTextTreatsMalformedMarkupAsPlain(t *testing.T) {
+	spans, styled := parseInlineText("Cut costs by **40%")
+	if styled || len(spans) != 1 || spans[0].Text != "Cut costs by **40%" {
+		t.Fatalf("expected raw plain text, got %+v styled=%v", spans, styled)
+	}
+
+	spans, styled = parseInlineText(`Rated 5\* overall`)
+	if styled || spans[0] != (richtext.Span{Text: "Rated 5* overall"}) {
+		t.Fatalf("expected escapes to resolve to plain text, got %+v styled=%v", spans, styled)
+	}
+}
@@ -0,0 +1,118 @@
+package pdfgen
+
+import (
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/richtext"
+)
+
+// fieldLink turns an entry field into a link: its text opens url, and a trailing token
+// such as "[GitHub]" opens tokenURL. Links apply to the left field of a row.
+type fieldLink struct {
+	url      string
+	token    string
+	tokenURL string
+}
+
+// projectLinks links a project's name to its site and appends a token for its
+// repository. Without a site, the name stays plain and only the token is a link.
+func projectLinks(url string, repoURL string) map[string]fieldLink {
+	link := fieldLink{url: strings.TrimSpace(url), tokenURL: strings.TrimSpace(repoURL)}
+	if link.url == "" && link.tokenURL == "" {
+		return nil
+	}
+	if link.tokenURL != "" {
+		link.token = repoLinkToken(link.tokenURL)
+	}
+	return map[string]fieldLink{"name": link}
+}
+
+// companyLinks links a company name to its website.
+func companyLinks(url string) map[string]fieldLink {
+	if strings.TrimSpace(url) == "" {
+		return nil
+	}
+	return map[string]fieldLink{"company": {url: strings.TrimSpace(url)}}
+}
+
+// repoLinkToken labels a repository link by its host, such as "[GitHub]" for
+// github.com; unknown hosts read "[Source]".
+func repoLinkToken(repoURL string) string {
+	host, _, _ := strings.Cut(strings.ToLower(displayURL(repoURL)), "/")
+	switch host {
+	case "github.com":
+		return "[GitHub]"
+	case "gitlab.com":
+		return "[GitLab]"
+	case "bitbucket.org":
+		return "[Bitbucket]"
+	case "codeberg.org":
+		return "[Codeberg]"
+	default:
+		return "[Source]"
+	}
+}
+
+// linkSpans returns text drawn in style as inline spans carrying link, or nil when link
+// has no target and the field is drawn as plain text. The token is drawn unstyled.
+func linkSpans(text string, style string, link fieldLink) []richtext.Span {
+	if link.url == "" && link.tokenURL == "" {
+		return nil
+	}
+	var spans []richtext.Span
+	if trimmed := strings.TrimSpace(text); trimmed != "" {
+		spans = append(spans, richtext.Span{
+			Text:   trimmed,
+			Bold:   strings.Contains(style, "B"),
+			Italic: strings.Contains(style, "I"),
+			URL:    link.url,
+		})
+	}
+	if link.tokenURL != "" {
+		if len(spans) > 0 {
+			spans = append(spans, richtext.Span{Text: " "})
+		}
+		spans = append(spans, richtext.Span{Text: link.token, URL: link.tokenURL})
+	}
+	return spans
+}
+
+// writeLinkedTwoColumnRow draws a two-column row like writeStyledTwoColumnRow, with the
+// left cell set from linked spans.
+func writeLinkedTwoColumnRow(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, style string, left []richtext.Span, right string, layout layoutConfig) {
+	leftWidth := twoColumnLeftWidth(pdf, layout)
+	leftLines := layoutInlineText(pdf, fontFamily, fontSize, left, leftWidth)
+	pdf.SetFont(fontFamily, style, fontSize)
+	rightLines := splitOrDefault(pdf, right, layout.rightColWidth)
+	lineCount := max(len(leftLines), len(rightLines))
+
+	ensureSpace(pdf, float64(lineCount)*layout.lineHeight, layout)
+	for i := 0; i < lineCount; i++ {
+		pdf.SetX(layout.leftMargin)
+		if i < len(leftLines) {
+			drawInlineLine(pdf, fontFamily, fontSize, leftLines[i], layout)
+		}
+		rightText := ""
+		if i < len(rightLines) {
+			rightText = rightLines[i]
+		}
+		pdf.SetFont(fontFamily, style, fontSize)
+		pdf.SetX(layout.leftMargin + leftWidth)
+		pdf.CellFormat(layout.rightColWidth, layout.lineHeight, rightText, "", 0, "R", false, 0, "")
+		pdf.Ln(layout.lineHeight)
+	}
+}
+
+// measureLinkedRow returns the height writeEntryRows uses for row when its left field is
+// set from linked spans.
+func measureLinkedRow(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, row rowSpec, left []richtext.Span, right string, layout layoutConfig) float64 {
+	if row.right == "" {
+		return float64(len(layoutInlineText(pdf, fontFamily, fontSize, left, layout.contentWidth()))) * layout.lineHeight
+	}
+	leftLines := layoutInlineText(pdf, fontFamily, fontSize, left, twoColumnLeftWidth(pdf, layout))
+	pdf.SetFont(fontFamily, row.style, fontSize)
+	rightLines := splitOrDefault(pdf, right, layout.rightColWidth)
+	return float64(max(len(leftLines), len(rightLines))) * layout.lineHeight
+}
@@ -0,0 +1,84 @@
+package pdfgen
+
+import (
+	"bytes"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestRepoLinkToken(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/ada/engine":   "[GitHub]",
+		"github.com/ada/engine":           "[GitHub]",
+		"https://www.GitLab.com/ada/loom": "[GitLab]",
+		"bitbucket.org/ada/notes":         "[Bitbucket]",
+		"https://git.example.com/engine":  "[Source]",
+	}
+	for repoURL, expected := range cases {
+		if actual := repoLinkToken(repoURL); actual != expected {
+			t.Fatalf("repoLinkToken(%q) = %q, want %q", repoURL, actual, expected)
+		}
+	}
+}
+
+func linkedEntriesRequest(withLinks bool) models.GeneratePDFRequest {
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Experience: []models.ExperienceEntry{
+				{Company: "Analytical Engines Ltd", Role: "Programmer", StartDate: "Jan 1842", EndDate: "Dec 1843"},
+			},
+			Projects: []models.ProjectEntry{
+				{Name: "Bernoulli Numbers", TechStack: "Difference Engine", StartDate: "Jan 1843", EndDate: "Sep 1843"},
+			},
+		},
+		Settings: models.ResumeSetting{FontSize: "medium", FontFamily: "times"},
+	}
+	if withLinks {
+		req.Data.Experience[0].URL = "engines.example.com"
+		req.Data.Projects[0].URL = "https://ada.example.com/bernoulli"
+		req.Data.Projects[0].RepoURL = "github.com/ada/bernoulli"
+	}
+	return req
+}
+
+func TestEntryLinksRenderAsNormalizedURIs(t *testing.T) {
+	plain, err := Generator{}.Generate(linkedEntriesRequest(false))
+	if err != nil {
+		t.Fatalf("generate plain PDF: %v", err)
+	}
+	linked, err := Generator{}.Generate(linkedEntriesRequest(true))
+	if err != nil {
+		t.Fatalf("generate linked PDF: %v", err)
+	}
+
+	if added := bytes.Count(linked, []byte("/URI (")) - bytes.Count(plain, []byte("/URI (")); added != 3 {
+		t.Fatalf("expected company, project and repository links, got %d new URIs", added)
+	}
+	for _, uri := range []string{"https://engines.example.com", "https://ada.example.com/bernoulli", "https://github.com/ada/bernoulli"} {
+		if !bytes.Contains(linked, []byte("("+uri+")")) {
+			t.Fatalf("expected PDF to link %s", uri)
+		}
+	}
+}
+
+func TestEntryLinksKeepEntryHeights(t *testing.T) {
+	plain, err := Generator{}.Layout(linkedEntriesRequest(false))
+	if err != nil {
+		t.Fatalf("layout plain request: %v", err)
+	}
+	linked, err := Generator{}.Layout(linkedEntriesRequest(true))
+	if err != nil {
+		t.Fatalf("layout linked request: %v", err)
+	}
+
+	for sectionIndex, section := range plain.Sections {
+		for entryIndex, entry := range section.Entries {
+			other := linked.Sections[sectionIndex].Entries[entryIndex]
+			if diff := (other.EndY - other.Y) - (entry.EndY - entry.Y); diff > 0.01 || diff < -0.01 {
+				t.Fatalf("%s entry %d changed height by %.2fmm when linked", section.Key, entryIndex, diff)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package pdfgen
+
+import (
+	"strings"
+
+	"resume_maker/backend/internal/models"
+)
+
+// pageSize is a portrait paper size in millimetres, named the way fpdf expects.
+type pageSize struct {
+	name   string
+	width  float64
+	height float64
+}
+
+var pageSizes = map[string]pageSize{
+	models.PageSizeA4:     {name: "A4", width: 210, height: 297},
+	models.PageSizeLetter: {name: "Letter", width: 215.9, height: 279.4},
+	models.PageSizeLegal:  {name: "Legal", width: 215.9, height: 355.6},
+}
+
+// marginPresets maps settings.margins presets to a uniform margin in millimetres.
+var marginPresets = map[string]float64{
+	models.MarginsNarrow: 12.7,
+	models.MarginsNormal: 20,
+	models.MarginsWide:   25.4,
+}
+
+// applyPageSettings returns layout with the paper size and margins requested in settings.
+// Unknown values and unset margin sides keep the template defaults; the service rejects
+// unknown values before rendering.
+func applyPageSettings(layout layoutConfig, settings models.ResumeSetting) layoutConfig {
+	if name, ok := models.NormalizePageSize(settings.PageSize); ok {
+		layout.page = pageSizes[name]
+	}
+
+	margins := settings.Margins
+	if margins == nil {
+		return layout
+	}
+	if margins.Preset != "" {
+		if margin, ok := marginPresets[strings.ToLower(strings.TrimSpace(margins.Preset))]; ok {
+			layout.topMargin, layout.rightMargin, layout.bottomMargin, layout.leftMargin = margin, margin, margin, margin
+		}
+		return layout
+	}
+	for _, side := range []struct {
+		value  *float64
+		target *float64
+	}{
+		{margins.Top, &layout.topMargin},
+		{margins.Right, &layout.rightMargin},
+		{margins.Bottom, &layout.bottomMargin},
+		{margins.Left, &layout.leftMargin},
+	} {
+		if side.value != nil {
+			*side.target = *side.value
+		}
+	}
+	return layout
+}
+
+// contentWidth is the horizontal space between the left and right margins.
+func (l layoutConfig) contentWidth() float64 {
+	return l.page.width - l.leftMargin - l.rightMargin
+}
+
+// usableHeight is the vertical space between the top and bottom margins.
+func (l layoutConfig) usableHeight() float64 {
+	return l.page.height - l.topMargin - l.bottomMargin
+}
@@ -0,0 +1,112 @@
+package pdfgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func millimetres(value float64) *float64 {
+	return &value
+}
+
+func TestApplyPageSettings(t *testing.T) {
+	base := defaultLayout()
+
+	letter := applyPageSettings(base, models.ResumeSetting{PageSize: "letter", Margins: &models.MarginSetting{Preset: "Narrow"}})
+	if letter.page != pageSizes[models.PageSizeLetter] {
+		t.Fatalf("expected Letter page, got %+v", letter.page)
+	}
+	if letter.topMargin != 12.7 || letter.leftMargin != 12.7 || letter.rightMargin != 12.7 || letter.bottomMargin != 12.7 {
+		t.Fatalf("expected narrow margins, got %+v", letter)
+	}
+	if math.Abs(letter.contentWidth()-190.5) > 0.001 {
+		t.Fatalf("expected content width 190.5mm, got %v", letter.contentWidth())
+	}
+
+	explicit := applyPageSettings(base, models.ResumeSetting{Margins: &models.MarginSetting{
+		Top: millimetres(10), Right: millimetres(15), Bottom: millimetres(12), Left: millimetres(18),
+	}})
+	if explicit.page != pageSizes[models.PageSizeA4] {
+		t.Fatalf("expected A4 to remain the default, got %+v", explicit.page)
+	}
+	if explicit.topMargin != 10 || explicit.rightMargin != 15 || explicit.bottomMargin != 12 || explicit.leftMargin != 18 {
+		t.Fatalf("expected explicit margins, got %+v", explicit)
+	}
+
+	partial := applyPageSettings(base, models.ResumeSetting{Margins: &models.MarginSetting{Top: millimetres(15)}})
+	if partial.topMargin != 15 || partial.rightMargin != base.rightMargin || partial.bottomMargin != base.bottomMargin || partial.leftMargin != base.leftMargin {
+		t.Fatalf("expected unset sides to keep the template margins, got %+v", partial)
+	}
+
+	unchanged := applyPageSettings(base, models.ResumeSetting{})
+	if unchanged.page != base.page || unchanged.topMargin != base.topMargin {
+		t.Fatalf("expected template layout without page settings, got %+v", unchanged)
+	}
+}
+
+func TestGenerateUsesRequestedPageSize(t *testing.T) {
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Projects:     []models.ProjectEntry{{Name: "Analytical Engine Notes"}},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium", PageSize: "Legal"},
+	}
+
+	pdfBytes, err := Generator{}.Generate(req)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !bytes.Contains(pdfBytes, []byte("/MediaBox [0 0 612.00 1008.00]")) {
+		t.Fatal("expected Legal media box in generated PDF")
+	}
+
+	report, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	if math.Abs(report.UsableHeight-315.6) > 0.001 {
+		t.Fatalf("expected usable height 315.6mm on Legal paper, got %v", report.UsableHeight)
+	}
+}
+
+func TestMarginSettingJSON(t *testing.T) {
+	var settings models.ResumeSetting
+	if err := json.Unmarshal([]byte(`{"margins":"wide"}`), &settings); err != nil {
+		t.Fatalf("unmarshal preset: %v", err)
+	}
+	if settings.Margins == nil || settings.Margins.Preset != "wide" {
+		t.Fatalf("expected wide preset, got %+v", settings.Margins)
+	}
+
+	if err := json.Unmarshal([]byte(`{"margins":{"top":10,"right":11,"bottom":12,"left":13}}`), &settings); err != nil {
+		t.Fatalf("unmarshal explicit margins: %v", err)
+	}
+	margins := settings.Margins
+	if margins.Preset != "" || *margins.Top != 10 || *margins.Right != 11 || *margins.Bottom != 12 || *margins.Left != 13 {
+		t.Fatalf("unexpected explicit margins %+v", margins)
+	}
+
+	encoded, err := json.Marshal(settings.Margins)
+	if err != nil {
+		t.Fatalf("marshal margins: %v", err)
+	}
+	if string(encoded) != `{"top":10,"right":11,"bottom":12,"left":13}` {
+		t.Fatalf("unexpected encoded margins %s", encoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"margins":{"top":15}}`), &settings); err != nil {
+		t.Fatalf("unmarshal partial margins: %v", err)
+	}
+	if *settings.Margins.Top != 15 || settings.Margins.Right != nil || settings.Margins.Bottom != nil || settings.Margins.Left != nil {
+		t.Fatalf("expected only the top margin to be set, got %+v", settings.Margins)
+	}
+
+	if err := json.Unmarshal([]byte(`{"margins":12}`), &settings); err == nil {
+		t.Fatal("expected numeric margins to be rejected")
+	}
+}
@@ -0,0 +1,111 @@
+package pdfgen
+
+import (
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+)
+
+// keepTogether starts a new page unless height fits below the cursor. Blocks taller than
+// a whole page are not moved, so they never leave an empty page behind.
+func keepTogether(pdf *fpdf.Fpdf, height float64, layout layoutConfig) {
+	if usableHeight := layout.usableHeight(); height > usableHeight {
+		height = usableHeight
+	}
+	ensureSpace(pdf, height, layout)
+}
+
+// nextPage continues on the following page, reusing a page another column already
+// added before appending a new one.
+func nextPage(pdf *fpdf.Fpdf, layout layoutConfig) {
+	if pdf.PageNo() < pdf.PageCount() {
+		movePage(pdf, pdf.PageNo()+1)
+		pdf.SetXY(layout.leftMargin, layout.topMargin)
+		return
+	}
+	pdf.AddPage()
+}
+
+// movePage switches to page n. fpdf writes font changes only into the page that was
+// active at the time, so the current font is re-emitted on the new page.
+func movePage(pdf *fpdf.Fpdf, n int) {
+	if n == pdf.PageNo() {
+		return
+	}
+	pdf.SetPage(n)
+	sizePt, _ := pdf.GetFontSize()
+	pdf.SetFontSize(sizePt + 1)
+	pdf.SetFontSize(sizePt)
+}
+
+// measureEntryHead returns the height of an entry's header rows plus the first
+// layout.keepBullets bullets, which must start on the same page. For a grouped entry the
+// head runs through its first role's rows and bullets.
+func measureEntryHead(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, rows []rowSpec, block entryBlock, layout layoutConfig) float64 {
+	if len(block.roles) > 0 {
+		headRows, roleRows := groupRows(layout)
+		first := block.roles[0]
+		return measureRowsAndBullets(pdf, fontFamily, fontSize, headRows, block.fields, block.links, nil, layout) +
+			measureRowsAndBullets(pdf, fontFamily, fontSize, roleRows, first.fields, nil, first.bullets, layout)
+	}
+	return measureRowsAndBullets(pdf, fontFamily, fontSize, rows, block.fields, block.links, block.bullets, layout)
+}
+
+// measureRowsAndBullets returns the height of rows drawn from fields and links plus the
+// first layout.keepBullets of bullets.
+func measureRowsAndBullets(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, rows []rowSpec, fields map[string]string, links map[string]fieldLink, bullets []models.Bullet, layout layoutConfig) float64 {
+	height := 0.0
+	for _, row := range rows {
+		if spans := linkSpans(fields[row.left], row.style, links[row.left]); spans != nil {
+			height += measureLinkedRow(pdf, fontFamily, fontSize, row, spans, fields[row.right], layout)
+			continue
+		}
+		if row.right == "" {
+			height += measureWrappedText(pdf, fontFamily, row.style, fontSize, fields[row.left], layout)
+			continue
+		}
+		height += measureTwoColumnRow(pdf, fontFamily, row.style, fontSize, fields[row.left], fields[row.right], layout)
+	}
+
+	kept := 0
+	for _, bullet := range bullets {
+		if kept >= layout.keepBullets {
+			break
+		}
+		if strings.TrimSpace(bullet.Text) == "" {
+			continue
+		}
+		height += measureBullet(pdf, fontFamily, fontSize, bullet.Text, 0, layout)
+		kept++
+	}
+
+	return height
+}
+
+// measureTwoColumnRow returns the height writeStyledTwoColumnRow uses for left and right.
+func measureTwoColumnRow(pdf *fpdf.Fpdf, fontFamily string, style string, fontSize float64, left string, right string, layout layoutConfig) float64 {
+	left = strings.TrimSpace(left)
+	right = strings.TrimSpace(right)
+	if left == "" && right == "" {
+		return 0
+	}
+
+	pdf.SetFont(fontFamily, style, fontSize)
+	leftLines := splitOrDefault(pdf, left, twoColumnLeftWidth(pdf, layout))
+	rightLines := splitOrDefault(pdf, right, layout.rightColWidth)
+	return float64(max(len(leftLines), len(rightLines))) * layout.lineHeight
+}
+
+// measureWrappedText returns the height writeWrappedText uses for value.
+func measureWrappedText(pdf *fpdf.Fpdf, fontFamily string, style string, fontSize float64, value string, layout layoutConfig) float64 {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0
+	}
+
+	pdf.SetFont(fontFamily, style, fontSize)
+	contentWidth := layout.contentWidth()
+	return float64(len(splitOrDefault(pdf, trimmed, contentWidth))) * layout.lineHeight
+}
@@ -0,0 +1,82 @@
+package pdfgen
+
+import (
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+)
+
+func newPaginationPDF(layout layoutConfig) *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(layout.leftMargin, layout.topMargin, layout.rightMargin)
+	pdf.SetAutoPageBreak(true, layout.bottomMargin)
+	pdf.AddPage()
+	return pdf
+}
+
+func paginationBlock() entryBlock {
+	return entryBlock{
+		id:     "exp-1",
+		label:  "Engineer",
+		fields: map[string]string{"role": "Engineer", "company": "Example Corp", "dates": "2020 - 2024"},
+		bullets: []models.Bullet{
+			{Text: "Shipped the billing service."},
+			{Text: "Cut p99 latency in half."},
+			{Text: "Mentored two new hires."},
+		},
+	}
+}
+
+func TestEntryMovesWithTitleWhenFirstBulletsDoNotFit(t *testing.T) {
+	layout := defaultLayout()
+	pdf := newPaginationPDF(layout)
+	_, pageHeight := pdf.GetPageSize()
+
+	// Leave room for the title and header rows but not the first two bullets.
+	rows := layout.entryRows[models.SectionExperience]
+	headerHeight := measureEntryHead(pdf, "Times", 11, rows, entryBlock{fields: paginationBlock().fields}, layout)
+	pdf.SetY(pageHeight - layout.bottomMargin - layout.lineHeight - layout.sectionSpacing - headerHeight - 1)
+
+	recorder := &layoutRecorder{}
+	renderEntrySection(pdf, models.SectionExperience, "Experience", []entryBlock{paginationBlock()}, "Times", 11, layout, recorder)
+
+	section := recorder.sections[0]
+	if section.Page != 2 {
+		t.Fatalf("expected section title to move to page 2, got page %d", section.Page)
+	}
+	if entry := section.Entries[0]; entry.Page != 2 || entry.EndPage != 2 {
+		t.Fatalf("expected entry to stay on page 2, got %+v", entry)
+	}
+}
+
+func TestKeepBulletsZeroOnlyKeepsHeaderRows(t *testing.T) {
+	layout := defaultLayout()
+	layout.keepBullets = 0
+	pdf := newPaginationPDF(layout)
+	_, pageHeight := pdf.GetPageSize()
+
+	rows := layout.entryRows[models.SectionExperience]
+	headerHeight := measureEntryHead(pdf, "Times", 11, rows, paginationBlock(), layout)
+	pdf.SetY(pageHeight - layout.bottomMargin - layout.lineHeight - layout.sectionSpacing - headerHeight - 1)
+
+	recorder := &layoutRecorder{}
+	renderEntrySection(pdf, models.SectionExperience, "Experience", []entryBlock{paginationBlock()}, "Times", 11, layout, recorder)
+
+	entry := recorder.sections[0].Entries[0]
+	if entry.Page != 1 || entry.EndPage != 2 {
+		t.Fatalf("expected entry to start on page 1 and spill onto page 2, got %+v", entry)
+	}
+}
+
+func TestKeepTogetherDoesNotBreakForBlocksTallerThanAPage(t *testing.T) {
+	layout := defaultLayout()
+	pdf := newPaginationPDF(layout)
+
+	keepTogether(pdf, 1000, layout)
+
+	if pdf.PageNo() != 1 {
+		t.Fatalf("expected oversized block at the top of a page to stay put, got page %d", pdf.PageNo())
+	}
+}
@@ -0,0 +1,95 @@
+package pdfgen
+
+import (
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+)
+
+// layoutRecorder collects where sections and entries land while a template renders,
+// and warnings about text that does not fit where it is drawn.
+type layoutRecorder struct {
+	sections []models.SectionPlacement
+	warnings []models.ValidationWarning
+}
+
+// checkOverflow warns about field when its text, drawn on a single line textWidth wide,
+// does not fit in width.
+func (r *layoutRecorder) checkOverflow(field string, textWidth float64, width float64, message string) {
+	if field == "" || textWidth <= width {
+		return
+	}
+	r.warnings = append(r.warnings, models.ValidationWarning{
+		Field:   field,
+		Code:    models.WarningOverflow,
+		Message: message,
+	})
+}
+
+func (r *layoutRecorder) beginSection(key string, title string, page int, y float64) {
+	r.sections = append(r.sections, models.SectionPlacement{
+		Key:   key,
+		Title: title,
+		Page:  page,
+		Y:     roundHundredths(y),
+	})
+}
+
+func (r *layoutRecorder) beginEntry(index int, id string, label string, page int, y float64) {
+	if len(r.sections) == 0 {
+		return
+	}
+	section := &r.sections[len(r.sections)-1]
+	section.Entries = append(section.Entries, models.EntryPlacement{
+		Index: index,
+		ID:    id,
+		Label: label,
+		Page:  page,
+		Y:     roundHundredths(y),
+	})
+}
+
+func (r *layoutRecorder) endEntry(page int, y float64) {
+	if len(r.sections) == 0 || len(r.sections[len(r.sections)-1].Entries) == 0 {
+		return
+	}
+	entries := r.sections[len(r.sections)-1].Entries
+	entries[len(entries)-1].EndPage = page
+	entries[len(entries)-1].EndY = roundHundredths(y)
+}
+
+// renderedDocument is a laid-out PDF that has not been serialized yet.
+type renderedDocument struct {
+	pdf      *fpdf.Fpdf
+	layout   layoutConfig
+	recorder *layoutRecorder
+}
+
+// report summarizes page usage and placements of the rendered document.
+func (d *renderedDocument) report() models.LayoutReport {
+	usableHeight := d.layout.usableHeight()
+	remaining := d.layout.page.height - d.layout.bottomMargin - d.pdf.GetY()
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	pages := d.pdf.PageCount()
+	filled := float64(pages)
+	if usableHeight > 0 {
+		filled = float64(pages-1) + (usableHeight-remaining)/usableHeight
+	}
+
+	sections := d.recorder.sections
+	if sections == nil {
+		sections = []models.SectionPlacement{}
+	}
+
+	return models.LayoutReport{
+		Pages:           pages,
+		FilledPages:     roundHundredths(filled),
+		UsableHeight:    roundHundredths(usableHeight),
+		RemainingHeight: roundHundredths(remaining),
+		Sections:        sections,
+		Warnings:        d.recorder.warnings,
+	}
+}
@@ -0,0 +1,70 @@
+package pdfgen
+
+import (
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestLayoutReportsSectionAndEntryPlacements(t *testing.T) {
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Experience: []models.ExperienceEntry{
+				{ID: "exp-1", Role: "Analyst", Company: "Engines Ltd", Bullets: []models.Bullet{{Text: "Wrote the first program."}}},
+				{ID: "exp-2", Role: "Translator", Company: "Taylor's Scientific Memoirs"},
+			},
+			Projects: []models.ProjectEntry{{Name: "Notes on the Analytical Engine"}},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium", SectionOrder: []string{"projects"}},
+	}
+
+	report, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	if report.Pages != 1 {
+		t.Fatalf("expected 1 page, got %d", report.Pages)
+	}
+	if report.RemainingHeight <= 0 || report.RemainingHeight >= report.UsableHeight {
+		t.Fatalf("expected remaining height within (0, %v), got %v", report.UsableHeight, report.RemainingHeight)
+	}
+	if report.FilledPages <= 0 || report.FilledPages >= 1 {
+		t.Fatalf("expected a partially filled single page, got %v", report.FilledPages)
+	}
+
+	if len(report.Sections) != 2 {
+		t.Fatalf("expected 2 section placements, got %+v", report.Sections)
+	}
+	if report.Sections[0].Key != models.SectionProjects || report.Sections[1].Key != models.SectionExperience {
+		t.Fatalf("expected projects then experience, got %s then %s", report.Sections[0].Key, report.Sections[1].Key)
+	}
+
+	experience := report.Sections[1]
+	if len(experience.Entries) != 2 {
+		t.Fatalf("expected 2 experience entries, got %+v", experience.Entries)
+	}
+	first, second := experience.Entries[0], experience.Entries[1]
+	if first.ID != "exp-1" || first.Label != "Analyst" || second.Index != 1 {
+		t.Fatalf("unexpected entry identity: %+v / %+v", first, second)
+	}
+	if !(experience.Y < first.Y && first.Y < first.EndY && first.EndY <= second.Y) {
+		t.Fatalf("expected increasing Y positions, got section=%v first=%+v second=%+v", experience.Y, first, second)
+	}
+}
+
+func TestLayoutReportsEntriesSpanningPageBreaks(t *testing.T) {
+	report, err := Generator{}.Layout(fitRequest(60))
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	if report.Pages < 2 || report.FilledPages <= 1 {
+		t.Fatalf("expected multi-page report, got pages=%d filled=%v", report.Pages, report.FilledPages)
+	}
+	entry := report.Sections[0].Entries[0]
+	if entry.Page != 1 || entry.EndPage != report.Pages {
+		t.Fatalf("expected entry to start on page 1 and end on page %d, got %+v", report.Pages, entry)
+	}
+}
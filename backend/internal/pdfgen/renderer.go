@@ -5,11 +5,13 @@ import (
 	_ "embed"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/go-pdf/fpdf"
 
+	"resume_maker/backend/internal/dates"
 	"resume_maker/backend/internal/models"
 )
 
@@ -17,6 +19,8 @@ import (
 type Generator struct{}
 
 type layoutConfig struct {
+	page           pageSize
+	theme          colorTheme
 	leftMargin     float64
 	rightMargin    float64
 	topMargin      float64
@@ -26,13 +30,47 @@ type layoutConfig struct {
 	entrySpacing   float64
 	rightColWidth  float64
 	skillLabelW    float64
+	keepBullets    int
+	bulletStyle    string
+	narrow         bool
+	fontSizeOffset float64
+	nameSizeDelta  float64
+	titleSizeDelta float64
+	titleStyle     string
+	titleCase      string
+	divider        string
+	sectionOrder   []string
+	sectionTitles  map[string]string
+	entryRows      map[string][]rowSpec
+	sidebar        sidebarLayout
+}
+
+// rowSpec composes one two-column entry row from named entry fields. A row without a
+// right field renders as full-width wrapped text.
+type rowSpec struct {
+	left  string
+	right string
+	style string
 }
 
 type contactToken struct {
-	text string
-	url  string
+	// field is the request path of the contact detail, used in overflow warnings.
+	field string
+	text  string
+	url   string
+	icon  string
 }
 
+const (
+	titleCaseUpper = "upper"
+	titleCaseTitle = "title"
+	titleCaseNone  = "none"
+
+	dividerLine  = "line"
+	dividerThick = "thick"
+	dividerNone  = "none"
+)
+
 const (
 	fontFamilyTimes    = "resume_times"
 	fontFamilyGaramond = "resume_garamond"
@@ -90,6 +128,8 @@ var arialBoldItalicFont []byte
 
 func defaultLayout() layoutConfig {
 	return layoutConfig{
+		page:           pageSizes[models.PageSizeA4],
+		theme:          themePresets[models.ThemeClassic],
 		leftMargin:     20,
 		rightMargin:    20,
 		topMargin:      20,
@@ -99,86 +139,153 @@ func defaultLayout() layoutConfig {
 		entrySpacing:   0.8,
 		rightColWidth:  52,
 		skillLabelW:    40,
+		keepBullets:    2,
+		bulletStyle:    models.BulletDisc,
+		nameSizeDelta:  5,
+		titleSizeDelta: 1,
+		titleStyle:     "B",
+		titleCase:      titleCaseUpper,
+		divider:        dividerLine,
+		sectionOrder: []string{
+			models.SectionEducation,
+			models.SectionExperience,
+			models.SectionProjects,
+			models.SectionTechnicalSkills,
+			models.SectionCustom,
+		},
+		sectionTitles: map[string]string{
+			models.SectionEducation:       "Education",
+			models.SectionExperience:      "Experience",
+			models.SectionProjects:        "Projects",
+			models.SectionTechnicalSkills: "Technical Skills",
+		},
+		entryRows: map[string][]rowSpec{
+			models.SectionEducation: {
+				{left: "institution", right: "location", style: "B"},
+				{left: "degree", right: "dates"},
+				{left: "honors"},
+				{left: "coursework", style: "I"},
+			},
+			models.SectionExperience: {
+				{left: "role", right: "dates", style: "B"},
+				{left: "company", right: "location"},
+			},
+			models.SectionProjects: {
+				{left: "name", right: "dates", style: "B"},
+				{left: "techStack", style: "I"},
+			},
+			models.SectionCustom: {
+				{left: "primary", right: "date", style: "B"},
+				{left: "secondary", right: "location"},
+			},
+		},
+	}
+}
+
+// Generate renders a deterministic PDF using the template named by req.TemplateID.
+func (g Generator) Generate(req models.GeneratePDFRequest) ([]byte, error) {
+	result, err := g.Render(req)
+	if err != nil {
+		return nil, err
 	}
+	return result.Content, nil
 }
 
-// Generate renders a deterministic single-template PDF for v1.
-func (Generator) Generate(req models.GeneratePDFRequest) ([]byte, error) {
-	layout := defaultLayout()
+// Render produces the PDF together with its page count and, when settings.fitToPages is
+// set, the layout adjustments applied to fit. A resume that cannot fit returns *FitError.
+func (Generator) Render(req models.GeneratePDFRequest) (models.GeneratedPDF, error) {
+	template, ok := LookupTemplate(req.TemplateID)
+	if !ok {
+		return models.GeneratedPDF{}, fmt.Errorf("unknown template %q", req.TemplateID)
+	}
+
+	doc, fit, err := renderFitted(template, req)
+	if err != nil {
+		return models.GeneratedPDF{}, err
+	}
 
-	pdf := fpdf.New("P", "mm", "A4", "")
+	var buf bytes.Buffer
+	if err := doc.pdf.Output(&buf); err != nil {
+		return models.GeneratedPDF{}, fmt.Errorf("render pdf: %w", err)
+	}
+
+	return models.GeneratedPDF{
+		Content:  buf.Bytes(),
+		Pages:    doc.pdf.PageCount(),
+		Fit:      fit,
+		Warnings: doc.recorder.warnings,
+	}, nil
+}
+
+// Layout runs the same layout as Render without serializing the PDF and reports page
+// usage plus the position of every section and entry.
+func (Generator) Layout(req models.GeneratePDFRequest) (models.LayoutReport, error) {
+	template, ok := LookupTemplate(req.TemplateID)
+	if !ok {
+		return models.LayoutReport{}, fmt.Errorf("unknown template %q", req.TemplateID)
+	}
+
+	doc, fit, err := renderFitted(template, req)
+	if err != nil {
+		return models.LayoutReport{}, err
+	}
+
+	report := doc.report()
+	report.Fit = fit
+	return report, nil
+}
+
+// renderTemplate draws req onto a fresh document using template's render function and layout.
+func renderTemplate(template Template, req models.GeneratePDFRequest, layout layoutConfig) (*renderedDocument, error) {
+	pdf := fpdf.New("P", "mm", layout.page.name, "")
 	if err := registerResumeFonts(pdf); err != nil {
 		return nil, fmt.Errorf("register resume fonts: %w", err)
 	}
 	pdf.SetCreationDate(time.Unix(0, 0))
 	pdf.SetCatalogSort(true)
 	pdf.SetTitle("Resume", false)
-	pdf.SetMargins(layout.leftMargin, layout.topMargin, layout.rightMargin)
-	pdf.SetAutoPageBreak(true, layout.bottomMargin)
-	pdf.AddPage()
-
-	fontFamily := mapFont(req.Settings.FontFamily)
-	fontSize := mapFontSize(req.Settings.FontSize)
 
-	if err := renderHeader(pdf, req, fontFamily, fontSize, layout); err != nil {
-		return nil, fmt.Errorf("render header: %w", err)
+	recorder := &layoutRecorder{}
+	if err := template.render(pdf, req, layout, recorder); err != nil {
+		return nil, fmt.Errorf("render template %s: %w", template.ID, err)
 	}
 
-	if len(req.Data.Education) > 0 {
-		addSectionTitle(pdf, fontFamily, fontSize, "Education", layout)
-		for _, edu := range req.Data.Education {
-			writeTwoColumnRow(pdf, fontFamily, fontSize, true, edu.Institution, edu.Location, layout)
-			writeTwoColumnRow(pdf, fontFamily, fontSize, false, edu.Degree, formatDateRange(edu.StartDate, edu.EndDate), layout)
-			for _, bullet := range edu.Bullets {
-				writeBullet(pdf, fontFamily, fontSize, bullet, layout)
-			}
-			pdf.Ln(layout.entrySpacing)
-		}
-	}
+	return &renderedDocument{pdf: pdf, layout: layout, recorder: recorder}, nil
+}
 
-	if len(req.Data.Experience) > 0 {
-		addSectionTitle(pdf, fontFamily, fontSize, "Experience", layout)
-		for _, exp := range req.Data.Experience {
-			writeTwoColumnRow(pdf, fontFamily, fontSize, true, exp.Role, formatDateRange(exp.StartDate, exp.EndDate), layout)
-			writeTwoColumnRow(pdf, fontFamily, fontSize, false, exp.Company, exp.Location, layout)
-			for _, bullet := range exp.Bullets {
-				writeBullet(pdf, fontFamily, fontSize, bullet, layout)
-			}
-			pdf.Ln(layout.entrySpacing)
-		}
-	}
+// HasTemplate reports whether id names a registered template.
+func (Generator) HasTemplate(id string) bool {
+	_, ok := LookupTemplate(id)
+	return ok
+}
 
-	if len(req.Data.Projects) > 0 {
-		addSectionTitle(pdf, fontFamily, fontSize, "Projects", layout)
-		for _, project := range req.Data.Projects {
-			writeTwoColumnRow(pdf, fontFamily, fontSize, true, project.Name, formatDateRange(project.StartDate, project.EndDate), layout)
-			if strings.TrimSpace(project.TechStack) != "" {
-				writeWrappedText(pdf, fontFamily, "I", fontSize, strings.TrimSpace(project.TechStack), layout)
-			}
-			for _, bullet := range project.Bullets {
-				writeBullet(pdf, fontFamily, fontSize, bullet, layout)
-			}
-			pdf.Ln(layout.entrySpacing)
-		}
-	}
+// renderSingleColumn draws the header and every section top to bottom using layout.
+func renderSingleColumn(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, layout layoutConfig, recorder *layoutRecorder) error {
+	pdf.SetMargins(layout.leftMargin, layout.topMargin, layout.rightMargin)
+	pdf.SetAutoPageBreak(true, layout.bottomMargin)
+	setTextColor(pdf, layout.theme.text)
+	pdf.AddPage()
 
-	if hasTechnicalSkills(req.Data.TechnicalSkills) {
-		addSectionTitle(pdf, fontFamily, fontSize, "Technical Skills", layout)
-		renderTechnicalSkillLine(pdf, fontFamily, fontSize, "Languages", req.Data.TechnicalSkills.Languages, layout)
-		renderTechnicalSkillLine(pdf, fontFamily, fontSize, "Frameworks", req.Data.TechnicalSkills.Frameworks, layout)
-		renderTechnicalSkillLine(pdf, fontFamily, fontSize, "Developer Tools", req.Data.TechnicalSkills.DeveloperTools, layout)
-		renderTechnicalSkillLine(pdf, fontFamily, fontSize, "Libraries", req.Data.TechnicalSkills.Libraries, layout)
+	fontFamily := mapFont(req.Settings.FontFamily)
+	fontSize := mapFontSize(req.Settings.FontSize) + layout.fontSizeOffset
+
+	if err := renderHeader(pdf, req, fontFamily, fontSize, layout, recorder); err != nil {
+		return fmt.Errorf("render header: %w", err)
 	}
+	renderSummary(pdf, req.Data.PersonalInfo.Summary, fontFamily, fontSize, layout)
 
-	var buf bytes.Buffer
-	if err := pdf.Output(&buf); err != nil {
-		return nil, fmt.Errorf("render pdf: %w", err)
+	for _, section := range resolveSectionOrder(layout.sectionOrder, req.Settings) {
+		renderSection(pdf, req, section, fontFamily, fontSize, layout, recorder)
 	}
 
-	return buf.Bytes(), nil
+	return nil
 }
 
-func renderHeader(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, fontFamily string, fontSize float64, layout layoutConfig) error {
+// renderHeader draws the name, headline, contact tokens, and photo in the arrangement
+// chosen by settings.headerLayout.
+func renderHeader(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, fontFamily string, fontSize float64, layout layoutConfig, recorder *layoutRecorder) error {
+	variant := normalizeHeaderLayout(req.Settings.HeaderLayout)
+
 	photoReservedWidth := 0.0
 	if req.Settings.ShowPhoto && strings.TrimSpace(req.Photo) != "" {
 		photoReservedWidth = 30
@@ -189,63 +296,124 @@ func renderHeader(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, fontFamily stri
 		headerHeight = 30
 	}
 	ensureSpace(pdf, headerHeight, layout)
+	top := pdf.GetY()
+
+	contentWidth := layout.contentWidth()
+	textX := layout.leftMargin
+	textBlockWidth := contentWidth - photoReservedWidth
+	if textBlockWidth <= 0 {
+		textBlockWidth = contentWidth
+	}
 
 	if photoReservedWidth > 0 {
-		if err := renderHeaderPhoto(pdf, req.Photo, layout); err != nil {
+		photoX := layout.page.width - layout.rightMargin - headerPhotoDiameter
+		if variant == models.HeaderPhotoLeft {
+			photoX = layout.leftMargin
+			textX += contentWidth - textBlockWidth
+		}
+		if err := renderHeaderPhoto(pdf, req.Photo, photoX, layout); err != nil {
 			return err
 		}
 	}
 
-	pageWidth, _ := pdf.GetPageSize()
-	contentWidth := pageWidth - layout.leftMargin - layout.rightMargin
-	textBlockWidth := contentWidth - photoReservedWidth
-	if textBlockWidth <= 0 {
-		textBlockWidth = contentWidth
+	align := "L"
+	if variant == models.HeaderCentered {
+		align = "C"
+	}
+	contactTokens := buildHeaderContactTokens(req.Data.PersonalInfo, contactOptionsFrom(req.Settings))
+	nameWidth := textBlockWidth
+	if variant == models.HeaderSplit && len(contactTokens) > 0 {
+		nameWidth = textBlockWidth * 0.55
 	}
 
-	pdf.SetFont(fontFamily, "B", fontSize+5)
+	pdf.SetFont(fontFamily, "B", fontSize+layout.nameSizeDelta)
 	fullName := strings.TrimSpace(req.Data.PersonalInfo.FirstName + " " + req.Data.PersonalInfo.LastName)
-	pdf.SetX(layout.leftMargin)
-	pdf.CellFormat(textBlockWidth, 8, fullName, "", 1, "C", false, 0, "")
+	recorder.checkOverflow("data.personalInfo", pdf.GetStringWidth(fullName), nameWidth, nameOverflowMessage)
+	pdf.SetX(textX)
+	setTextColor(pdf, layout.theme.accent)
+	pdf.CellFormat(nameWidth, 8, fullName, "", 1, align, false, 0, "")
+	setTextColor(pdf, layout.theme.text)
+
+	if headline := strings.TrimSpace(req.Data.PersonalInfo.Headline); headline != "" {
+		headlineLayout := layout
+		headlineLayout.leftMargin = textX
+		headlineLayout.rightMargin = layout.page.width - textX - nameWidth
+		writeWrappedTextAligned(pdf, fontFamily, "I", fontSize+1, headline, align, headlineLayout)
+	}
 
-	contactTokens := buildHeaderContactTokens(req.Data.PersonalInfo)
 	if len(contactTokens) > 0 {
-		renderCenteredContactTokens(pdf, fontFamily, fontSize, contactTokens, layout, layout.leftMargin, textBlockWidth)
+		if variant == models.HeaderSplit {
+			nameEnd := pdf.GetY()
+			pdf.SetY(top)
+			renderContactTokens(pdf, fontFamily, fontSize, contactTokens, layout, textX+nameWidth, textBlockWidth-nameWidth, "R", recorder)
+			if pdf.GetY() < nameEnd {
+				pdf.SetY(nameEnd)
+			}
+		} else {
+			renderContactTokens(pdf, fontFamily, fontSize, contactTokens, layout, textX, textBlockWidth, align, recorder)
+		}
+	}
+
+	// A photo on the left would otherwise sit under the first section.
+	if variant == models.HeaderPhotoLeft && photoReservedWidth > 0 && pdf.GetY() < layout.topMargin+headerPhotoDiameter {
+		pdf.SetY(layout.topMargin + headerPhotoDiameter)
 	}
 	pdf.Ln(2)
 	return nil
 }
 
-func addSectionTitle(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, title string, layout layoutConfig) {
-	ensureSpace(pdf, layout.lineHeight*2, layout)
-	pdf.SetFont(fontFamily, "B", fontSize+1)
-	pdf.MultiCell(0, layout.lineHeight, strings.ToUpper(title), "", "L", false)
+// normalizeHeaderLayout maps settings.headerLayout to a known variant, defaulting to centered.
+func normalizeHeaderLayout(value string) string {
+	for _, variant := range models.HeaderLayouts {
+		if strings.EqualFold(strings.TrimSpace(value), variant) {
+			return variant
+		}
+	}
+	return models.HeaderCentered
+}
+
+// renderSummary draws the optional summary paragraph between the header and the first section.
+func renderSummary(pdf *fpdf.Fpdf, summary string, fontFamily string, fontSize float64, layout layoutConfig) {
+	if strings.TrimSpace(summary) == "" {
+		return
+	}
+	writeWrappedTextAligned(pdf, fontFamily, "", fontSize, summary, "L", layout)
+	pdf.Ln(layout.sectionSpacing)
+}
 
-	y := pdf.GetY()
-	pageWidth, _ := pdf.GetPageSize()
-	pdf.Line(layout.leftMargin, y, pageWidth-layout.rightMargin, y)
+// addSectionTitle draws a section heading and returns the page and Y position where it
+// starts. The heading moves to the next page unless followHeight of content fits below it.
+func addSectionTitle(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, title string, followHeight float64, layout layoutConfig) (int, float64) {
+	keepTogether(pdf, math.Max(layout.lineHeight*2, layout.lineHeight+layout.sectionSpacing+followHeight), layout)
+	page, startY := pdf.PageNo(), pdf.GetY()
+	pdf.SetFont(fontFamily, layout.titleStyle, fontSize+layout.titleSizeDelta)
+	setTextColor(pdf, layout.theme.accent)
+	pdf.MultiCell(0, layout.lineHeight, applyTitleCase(title, layout.titleCase), "", "L", false)
+	setTextColor(pdf, layout.theme.text)
+
+	if layout.divider != dividerNone {
+		y := pdf.GetY()
+		if layout.divider == dividerThick {
+			pdf.SetLineWidth(0.6)
+		}
+		setDrawColor(pdf, layout.theme.rule)
+		pdf.Line(layout.leftMargin, y, layout.page.width-layout.rightMargin, y)
+		setDrawColor(pdf, black)
+		pdf.SetLineWidth(0.2)
+	}
 	pdf.Ln(layout.sectionSpacing)
+	return page, startY
 }
 
-func writeTwoColumnRow(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, bold bool, left string, right string, layout layoutConfig) {
+func writeStyledTwoColumnRow(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, style string, left string, right string, layout layoutConfig) {
 	left = strings.TrimSpace(left)
 	right = strings.TrimSpace(right)
 	if left == "" && right == "" {
 		return
 	}
 
-	style := ""
-	if bold {
-		style = "B"
-	}
 	pdf.SetFont(fontFamily, style, fontSize)
-
-	pageWidth, _ := pdf.GetPageSize()
-	contentWidth := pageWidth - layout.leftMargin - layout.rightMargin
-	leftWidth := contentWidth - layout.rightColWidth
-	if leftWidth < 60 {
-		leftWidth = contentWidth * 0.7
-	}
+	leftWidth := twoColumnLeftWidth(pdf, layout)
 
 	leftLines := splitOrDefault(pdf, left, leftWidth)
 	rightLines := splitOrDefault(pdf, right, layout.rightColWidth)
@@ -272,12 +440,14 @@ func writeTwoColumnRow(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, bold
 	}
 }
 
-func writeBullet(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, bullet string, layout layoutConfig) {
-	trimmed := strings.TrimSpace(bullet)
-	if trimmed == "" {
-		return
+// twoColumnLeftWidth returns the width of the left cell in a two-column row.
+func twoColumnLeftWidth(pdf *fpdf.Fpdf, layout layoutConfig) float64 {
+	contentWidth := layout.contentWidth()
+	leftWidth := contentWidth - layout.rightColWidth
+	if leftWidth < 60 {
+		leftWidth = contentWidth * 0.7
 	}
-	writeWrappedText(pdf, fontFamily, "", fontSize, "- "+trimmed, layout)
+	return leftWidth
 }
 
 func writeWrappedText(pdf *fpdf.Fpdf, fontFamily string, style string, fontSize float64, value string, layout layoutConfig) {
@@ -291,31 +461,67 @@ func writeWrappedTextAligned(pdf *fpdf.Fpdf, fontFamily string, style string, fo
 	}
 
 	pdf.SetFont(fontFamily, style, fontSize)
-	pageWidth, _ := pdf.GetPageSize()
-	contentWidth := pageWidth - layout.leftMargin - layout.rightMargin
+	contentWidth := layout.contentWidth()
 	lines := splitOrDefault(pdf, trimmed, contentWidth)
 
 	for _, line := range lines {
 		ensureSpace(pdf, layout.lineHeight, layout)
-		pdf.CellFormat(0, layout.lineHeight, line, "", 1, align, false, 0, "")
+		pdf.SetX(layout.leftMargin)
+		pdf.CellFormat(contentWidth, layout.lineHeight, line, "", 1, align, false, 0, "")
+	}
+}
+
+// renderTechnicalSkills draws one line per skill category, with the label column as
+// wide as the longest label.
+func renderTechnicalSkills(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, skills models.TechnicalSkills, layout layoutConfig) {
+	labelWidth := skillLabelWidth(pdf, fontFamily, fontSize, skills, layout)
+	for _, category := range skills {
+		renderTechnicalSkillLine(pdf, fontFamily, fontSize, strings.TrimSpace(category.Label), strings.Join(category.Items(), ", "), labelWidth, layout)
 	}
 }
 
-func renderTechnicalSkillLine(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, label string, value string, layout layoutConfig) {
+// maxSkillLabelShare is the share of the content width the skill label column may
+// widen to for long category labels.
+const maxSkillLabelShare = 0.35
+
+// skillLabelWidth fits the label column to the widest bold "label:". It widens past the
+// template's skillLabelW up to maxSkillLabelShare of the content width; longer labels
+// wrap within that.
+func skillLabelWidth(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, skills models.TechnicalSkills, layout layoutConfig) float64 {
+	pdf.SetFont(fontFamily, "B", fontSize)
+	widest := 0.0
+	for _, category := range skills {
+		if len(category.Items()) > 0 {
+			widest = math.Max(widest, pdf.GetStringWidth(strings.TrimSpace(category.Label)+":"))
+		}
+	}
+	// Cells pad their text by the cell margin on each side; the extra 0.1mm keeps the
+	// widest label from wrapping on rounding.
+	limit := math.Max(layout.skillLabelW, layout.contentWidth()*maxSkillLabelShare)
+	return math.Min(widest+2*pdf.GetCellMargin()+0.1, limit)
+}
+
+func renderTechnicalSkillLine(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, label string, value string, labelWidth float64, layout layoutConfig) {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
 		return
 	}
 
-	pageWidth, _ := pdf.GetPageSize()
-	contentWidth := pageWidth - layout.leftMargin - layout.rightMargin
-	valueWidth := contentWidth - layout.skillLabelW
+	contentWidth := layout.contentWidth()
+	valueWidth := contentWidth - labelWidth
 	if valueWidth < 40 {
 		valueWidth = contentWidth * 0.6
 	}
 
+	if layout.narrow {
+		ensureSpace(pdf, layout.lineHeight*2, layout)
+		writeWrappedText(pdf, fontFamily, "B", fontSize, label, layout)
+		writeWrappedText(pdf, fontFamily, "", fontSize, trimmed, layout)
+		return
+	}
+
 	pdf.SetFont(fontFamily, "B", fontSize)
-	labelLines := splitOrDefault(pdf, label+":", layout.skillLabelW)
+	labelLines := splitOrDefault(pdf, label+":", labelWidth)
 	pdf.SetFont(fontFamily, "", fontSize)
 	valueLines := splitOrDefault(pdf, trimmed, valueWidth)
 
@@ -335,7 +541,7 @@ func renderTechnicalSkillLine(pdf *fpdf.Fpdf, fontFamily string, fontSize float6
 		}
 
 		pdf.SetFont(fontFamily, "B", fontSize)
-		pdf.CellFormat(layout.skillLabelW, layout.lineHeight, labelText, "", 0, "L", false, 0, "")
+		pdf.CellFormat(labelWidth, layout.lineHeight, labelText, "", 0, "L", false, 0, "")
 		pdf.SetFont(fontFamily, "", fontSize)
 		pdf.CellFormat(valueWidth, layout.lineHeight, valueText, "", 0, "L", false, 0, "")
 		pdf.Ln(-1)
@@ -344,9 +550,8 @@ func renderTechnicalSkillLine(pdf *fpdf.Fpdf, fontFamily string, fontSize float6
 }
 
 func ensureSpace(pdf *fpdf.Fpdf, neededHeight float64, layout layoutConfig) {
-	_, pageHeight := pdf.GetPageSize()
-	if pdf.GetY()+neededHeight > pageHeight-layout.bottomMargin {
-		pdf.AddPage()
+	if pdf.GetY()+neededHeight > layout.page.height-layout.bottomMargin {
+		nextPage(pdf, layout)
 	}
 }
 
@@ -365,16 +570,15 @@ func splitOrDefault(pdf *fpdf.Fpdf, value string, width float64) []string {
 	return lines
 }
 
-func hasTechnicalSkills(skills models.TechnicalSkills) bool {
-	return strings.TrimSpace(skills.Languages) != "" ||
-		strings.TrimSpace(skills.Frameworks) != "" ||
-		strings.TrimSpace(skills.DeveloperTools) != "" ||
-		strings.TrimSpace(skills.Libraries) != ""
-}
-
-func formatDateRange(startDate string, endDate string) string {
-	start := strings.TrimSpace(startDate)
-	end := strings.TrimSpace(endDate)
+// formatDateRange joins an entry's dates. With a date style the dates are normalized and
+// joined by an en dash; without one they are shown as entered.
+func formatDateRange(startDate string, endDate string, style string) string {
+	start := formatDate(startDate, style)
+	end := formatDate(endDate, style)
+	separator := " - "
+	if style != "" {
+		separator = " \u2013 "
+	}
 	switch {
 	case start == "" && end == "":
 		return ""
@@ -383,8 +587,34 @@ func formatDateRange(startDate string, endDate string) string {
 	case end == "":
 		return start
 	default:
-		return start + " - " + end
+		return start + separator + end
+	}
+}
+
+// formatDate writes value in style, leaving it as entered when there is no style or the
+// value does not parse.
+func formatDate(value string, style string) string {
+	trimmed := strings.TrimSpace(value)
+	if style == "" || trimmed == "" {
+		return trimmed
+	}
+	date, err := dates.Parse(trimmed)
+	if err != nil {
+		return trimmed
 	}
+	return date.Format(style)
+}
+
+// normalizeDateFormat returns the known date style named by value, or "" to keep dates
+// as entered.
+func normalizeDateFormat(value string) string {
+	style := strings.ToLower(strings.TrimSpace(value))
+	for _, known := range models.DateFormats {
+		if style == known {
+			return style
+		}
+	}
+	return ""
 }
 
 func mapFont(fontFamily string) string {
@@ -472,35 +702,52 @@ func max(a int, b int) int {
 	return b
 }
 
-func buildHeaderContactTokens(info models.PersonalInfo) []contactToken {
+// buildHeaderContactTokens lists the contact details in header order. With
+// options.shortenLinks, link text is shown without its scheme, "www." or trailing slash;
+// labelled links keep their label.
+func buildHeaderContactTokens(info models.PersonalInfo, options contactOptions) []contactToken {
 	tokens := make([]contactToken, 0, 8)
 
-	appendToken := func(text string, url string) {
+	appendToken := func(field string, text string, url string, icon string) {
 		trimmedText := strings.TrimSpace(text)
 		if trimmedText == "" {
 			return
 		}
-		tokens = append(tokens, contactToken{text: trimmedText, url: normalizeLinkURL(url)})
+		token := contactToken{field: field, text: trimmedText, url: normalizeLinkURL(url)}
+		if options.shortenLinks && trimmedText == strings.TrimSpace(url) && displayURL(trimmedText) != "" {
+			token.text = displayURL(trimmedText)
+		}
+		if options.icons {
+			token.icon = icon
+		}
+		tokens = append(tokens, token)
 	}
 
-	appendToken(info.Phone, "")
-	appendToken(info.Email, "mailto:"+strings.TrimSpace(info.Email))
-	appendToken(info.LinkedIn, info.LinkedIn)
-	appendToken(info.GitHub, info.GitHub)
-	appendToken(info.Website, info.Website)
+	appendToken("data.personalInfo.phone", info.Phone, "", iconPhone)
+	appendToken("data.personalInfo.email", info.Email, "mailto:"+strings.TrimSpace(info.Email), iconMail)
+	appendToken("data.personalInfo.linkedin", info.LinkedIn, info.LinkedIn, iconLinkedIn)
+	appendToken("data.personalInfo.github", info.GitHub, info.GitHub, iconGitHub)
+	appendToken("data.personalInfo.website", info.Website, info.Website, iconGlobe)
 
-	for _, link := range info.OtherLinks {
+	for index, link := range info.OtherLinks {
 		display := strings.TrimSpace(link.Label)
 		if display == "" {
 			display = strings.TrimSpace(link.URL)
 		}
-		appendToken(display, link.URL)
+		appendToken(fmt.Sprintf("data.personalInfo.otherLinks[%d]", index), display, link.URL, iconGlobe)
 	}
 
 	return tokens
 }
 
-func renderHeaderPhoto(pdf *fpdf.Fpdf, photo string, layout layoutConfig) error {
+// nameOverflowMessage explains an overflow warning for a full name too wide for its line.
+const nameOverflowMessage = "full name is wider than the header line and will run past its edge"
+
+// headerPhotoDiameter is the size of the circular header photo in millimetres.
+const headerPhotoDiameter = 24.0
+
+// renderHeaderPhoto draws the circular photo with its left edge at photoX.
+func renderHeaderPhoto(pdf *fpdf.Fpdf, photo string, photoX float64, layout layoutConfig) error {
 	imageType, imageBytes, err := decodePhotoDataURL(photo)
 	if err != nil {
 		return fmt.Errorf("decode photo data url: %w", err)
@@ -510,13 +757,10 @@ func renderHeaderPhoto(pdf *fpdf.Fpdf, photo string, layout layoutConfig) error
 		return fmt.Errorf("empty decoded photo payload")
 	}
 
-	const photoDiameter = 24.0
-	pageWidth, _ := pdf.GetPageSize()
-	photoX := pageWidth - layout.rightMargin - photoDiameter
 	photoY := layout.topMargin
-	centerX := photoX + (photoDiameter / 2)
-	centerY := photoY + (photoDiameter / 2)
-	radius := photoDiameter / 2
+	centerX := photoX + (headerPhotoDiameter / 2)
+	centerY := photoY + (headerPhotoDiameter / 2)
+	radius := headerPhotoDiameter / 2
 
 	imageName := "profile-photo"
 	options := fpdf.ImageOptions{ImageType: imageType}
@@ -526,16 +770,16 @@ func renderHeaderPhoto(pdf *fpdf.Fpdf, photo string, layout layoutConfig) error
 	}
 
 	pdf.ClipCircle(centerX, centerY, radius, false)
-	pdf.ImageOptions(imageName, photoX, photoY, photoDiameter, photoDiameter, false, options, 0, "")
+	pdf.ImageOptions(imageName, photoX, photoY, headerPhotoDiameter, headerPhotoDiameter, false, options, 0, "")
 	if pdf.Err() {
 		return fmt.Errorf("draw photo image: %w", pdf.Error())
 	}
 	pdf.ClipEnd()
 
-	pdf.SetDrawColor(133, 149, 160)
+	setDrawColor(pdf, layout.theme.rule)
 	pdf.SetLineWidth(0.35)
 	pdf.Circle(centerX, centerY, radius, "D")
-	pdf.SetDrawColor(0, 0, 0)
+	setDrawColor(pdf, black)
 	pdf.SetLineWidth(0.2)
 
 	return nil
@@ -567,7 +811,9 @@ func decodePhotoDataURL(photo string) (string, []byte, error) {
 	return imageType, decoded, nil
 }
 
-func renderCenteredContactTokens(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, tokens []contactToken, layout layoutConfig, baseX float64, contentWidth float64) {
+// renderContactTokens wraps tokens across contentWidth starting at baseX, aligning each
+// line left ("L"), centered ("C"), or right ("R"). Tokens with a URL become links.
+func renderContactTokens(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, tokens []contactToken, layout layoutConfig, baseX float64, contentWidth float64, align string, recorder *layoutRecorder) {
 	pdf.SetFont(fontFamily, "", fontSize)
 
 	lines := make([][]contactToken, 0, 2)
@@ -575,7 +821,8 @@ func renderCenteredContactTokens(pdf *fpdf.Fpdf, fontFamily string, fontSize flo
 	currentWidth := 0.0
 
 	for i, token := range tokens {
-		segmentWidth := pdf.GetStringWidth(token.text)
+		segmentWidth := contactTokenWidth(pdf, token, fontSize)
+		recorder.checkOverflow(token.field, segmentWidth, contentWidth, "is wider than the contact line and will run past its edge")
 		separatorWidth := 0.0
 		if i > 0 {
 			separatorWidth = pdf.GetStringWidth(" | ")
@@ -604,22 +851,31 @@ func renderCenteredContactTokens(pdf *fpdf.Fpdf, fontFamily string, fontSize flo
 	for _, line := range lines {
 		lineWidth := 0.0
 		for _, token := range line {
-			lineWidth += pdf.GetStringWidth(token.text)
+			lineWidth += contactTokenWidth(pdf, token, fontSize)
 		}
 
 		ensureSpace(pdf, layout.lineHeight, layout)
-		startX := baseX + (contentWidth-lineWidth)/2
+		startX := baseX
+		switch align {
+		case "C":
+			startX = baseX + (contentWidth-lineWidth)/2
+		case "R":
+			startX = baseX + contentWidth - lineWidth
+		}
 		if startX < baseX {
 			startX = baseX
 		}
 		pdf.SetX(startX)
 
 		for _, token := range line {
+			if token.icon != "" {
+				drawTokenIcon(pdf, token, fontFamily, fontSize, layout)
+			}
 			width := pdf.GetStringWidth(token.text)
 			if token.url != "" {
-				pdf.SetTextColor(47, 95, 121)
+				setTextColor(pdf, layout.theme.link)
 				pdf.CellFormat(width, layout.lineHeight, token.text, "", 0, "L", false, 0, token.url)
-				pdf.SetTextColor(0, 0, 0)
+				setTextColor(pdf, layout.theme.text)
 			} else {
 				pdf.CellFormat(width, layout.lineHeight, token.text, "", 0, "L", false, 0, "")
 			}
@@ -2,6 +2,8 @@ package pdfgen
 
 import (
 	"bytes"
+	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -10,7 +12,7 @@ import (
 	"resume_maker/backend/internal/models"
 )
 
-func TestWriteTwoColumnRowNearPageBottomMovesToNewPage(t *testing.T) {
+func TestWriteEntryRowsNearPageBottomMovesToNewPage(t *testing.T) {
 	pdf := fpdf.New("P", "mm", "A4", "")
 	layout := defaultLayout()
 	pdf.SetMargins(layout.leftMargin, layout.topMargin, layout.rightMargin)
@@ -18,13 +20,16 @@ func TestWriteTwoColumnRowNearPageBottomMovesToNewPage(t *testing.T) {
 	pdf.AddPage()
 	pdf.SetY(274)
 
-	writeTwoColumnRow(
+	writeEntryRows(
 		pdf,
 		"Times",
 		11,
-		true,
-		strings.Repeat("Long left content wraps to next line repeatedly ", 6),
-		"Apr 2025 - Dec 2025",
+		[]rowSpec{{left: "role", right: "dates", style: "B"}},
+		map[string]string{
+			"role":  strings.Repeat("Long left content wraps to next line repeatedly ", 6),
+			"dates": "Apr 2025 - Dec 2025",
+		},
+		nil,
 		layout,
 	)
 
@@ -71,8 +76,8 @@ func TestGeneratePDFDiffersByFontFamily(t *testing.T) {
 					Company:   "Example Corp",
 					StartDate: "Apr 2025",
 					EndDate:   "Dec 2025",
-					Bullets: []string{
-						"Implemented concurrent backend services.",
+					Bullets: []models.Bullet{
+						{Text: "Implemented concurrent backend services."},
 					},
 				},
 			},
@@ -118,7 +123,7 @@ func TestGeneratePDFIncludesImageWhenPhotoEnabled(t *testing.T) {
 				Email:     "ada@example.com",
 			},
 			TechnicalSkills: models.TechnicalSkills{
-				Languages: "Go",
+				{Label: "Languages", Skills: []string{"Go"}},
 			},
 		},
 		Settings: models.ResumeSetting{
@@ -140,3 +145,94 @@ func TestGeneratePDFIncludesImageWhenPhotoEnabled(t *testing.T) {
 		t.Fatal("expected generated PDF to contain embedded image object")
 	}
 }
+
+func TestFormatDateRange(t *testing.T) {
+	tests := []struct {
+		start, end, style string
+		want              string
+	}{
+		{"June 2020", "present", "", "June 2020 - present"},
+		{"2022-01", "present", models.DateFormatShort, "Jan 2022 – Present"},
+		{"Jan 2022", "now", models.DateFormatNumeric, "01/2022 – Now"},
+		{"01/2019", "2021-03", models.DateFormatLong, "January 2019 – March 2021"},
+		{"2019", "", models.DateFormatISO, "2019"},
+		{"Summer 2019", "Fall 2019", models.DateFormatShort, "Summer 2019 – Fall 2019"},
+	}
+	for _, tt := range tests {
+		if got := formatDateRange(tt.start, tt.end, tt.style); got != tt.want {
+			t.Fatalf("formatDateRange(%q, %q, %q) = %q, want %q", tt.start, tt.end, tt.style, got, tt.want)
+		}
+	}
+
+	if got := normalizeDateFormat(" Numeric "); got != models.DateFormatNumeric {
+		t.Fatalf("expected numeric date format, got %q", got)
+	}
+	if got := normalizeDateFormat("roman"); got != "" {
+		t.Fatalf("expected unknown date format to keep dates as entered, got %q", got)
+	}
+}
+
+func TestTechnicalSkillsDecodesCategoriesAndLegacyFields(t *testing.T) {
+	var legacy models.TechnicalSkills
+	if err := json.Unmarshal([]byte(`{"languages":"Go, Python,","developerTools":" ","libraries":"NumPy"}`), &legacy); err != nil {
+		t.Fatalf("decode legacy skills: %v", err)
+	}
+	want := models.TechnicalSkills{
+		{Label: "Languages", Skills: []string{"Go", "Python"}},
+		{Label: "Libraries", Skills: []string{"NumPy"}},
+	}
+	if !reflect.DeepEqual(legacy, want) {
+		t.Fatalf("expected legacy fields as %+v, got %+v", want, legacy)
+	}
+
+	var categories models.TechnicalSkills
+	if err := json.Unmarshal([]byte(`[{"label":"ML/AI","skills":["PyTorch","JAX"]},{"label":"Cloud","skills":["GCP"]}]`), &categories); err != nil {
+		t.Fatalf("decode skill categories: %v", err)
+	}
+	if len(categories) != 2 || categories[0].Label != "ML/AI" || categories[1].Skills[0] != "GCP" {
+		t.Fatalf("expected categories in order, got %+v", categories)
+	}
+
+	if err := json.Unmarshal([]byte(`"Go, Python"`), &categories); err == nil {
+		t.Fatal("expected a plain string to be rejected")
+	}
+}
+
+func TestSkillLabelWidthFollowsLongestLabel(t *testing.T) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	layout := defaultLayout()
+
+	short := skillLabelWidth(pdf, "Times", 11, models.TechnicalSkills{{Label: "ML", Skills: []string{"JAX"}}}, layout)
+	long := skillLabelWidth(pdf, "Times", 11, models.TechnicalSkills{
+		{Label: "ML", Skills: []string{"JAX"}},
+		{Label: "Design Tools", Skills: []string{"Figma"}},
+		{Label: "An Unusually Long Category Label", Skills: nil},
+	}, layout)
+	if short >= long {
+		t.Fatalf("expected the label column to grow with the longest label, got %.2f then %.2f", short, long)
+	}
+	if long >= layout.skillLabelW {
+		t.Fatalf("expected labels without skills to be ignored, got width %.2f", long)
+	}
+
+	pdf.SetFont("Times", "B", 11)
+	if lines := pdf.SplitText("Design Tools:", long); len(lines) != 1 {
+		t.Fatalf("expected the longest label to fit on one line, got %q", lines)
+	}
+
+	wide := skillLabelWidth(pdf, "Times", 11, models.TechnicalSkills{{Label: "Infrastructure & Observability", Skills: []string{"Terraform"}}}, layout)
+	if wide <= layout.skillLabelW {
+		t.Fatalf("expected a long label to widen the column past %.2f, got %.2f", layout.skillLabelW, wide)
+	}
+	pdf.SetFont("Times", "B", 11)
+	if lines := pdf.SplitText("Infrastructure & Observability:", wide); len(lines) != 1 {
+		t.Fatalf("expected the long label to fit on one line, got %q", lines)
+	}
+
+	limit := layout.contentWidth() * maxSkillLabelShare
+	capped := skillLabelWidth(pdf, "Times", 11, models.TechnicalSkills{{Label: strings.Repeat("Infrastructure ", 6), Skills: []string{"Terraform"}}}, layout)
+	if capped != limit {
+		t.Fatalf("expected label column capped at %.2f, got %.2f", limit, capped)
+	}
+}
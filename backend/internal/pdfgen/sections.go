@@ -0,0 +1,366 @@
+package pdfgen
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+	"resume_maker/backend/internal/variant"
+)
+
+// entryBlock is one section entry reduced to what the flow renderer needs. An entry with
+// roles draws groupHeadRows from its fields and then each role in turn. index is the
+// entry's position in the request, kept when entries are sorted; start and end are the
+// raw dates used for sorting. links makes some fields clickable.
+type entryBlock struct {
+	index      int
+	id         string
+	label      string
+	start, end string
+	fields     map[string]string
+	links      map[string]fieldLink
+	bullets    []models.Bullet
+	roles      []roleBlock
+}
+
+// roleBlock is one role of a grouped experience entry.
+type roleBlock struct {
+	start, end string
+	fields     map[string]string
+	bullets    []models.Bullet
+}
+
+// Grouped experience entries show the company once, then each role with its own dates.
+var (
+	groupHeadRows = []rowSpec{{left: "company", right: "location", style: "B"}}
+	groupRoleRows = []rowSpec{{left: "role", right: "dates", style: "I"}}
+)
+
+// renderSection draws one section by key; empty sections are skipped. Entries and
+// bullets left out of req.Variant are not drawn.
+func renderSection(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, section string, fontFamily string, fontSize float64, layout layoutConfig, recorder *layoutRecorder) {
+	title := layout.sectionTitles[section]
+	dateStyle := normalizeDateFormat(req.Settings.DateFormat)
+	filter := variant.NewFilter(req.Variant)
+
+	switch section {
+	case models.SectionEducation:
+		blocks := make([]entryBlock, 0, len(req.Data.Education))
+		for index, edu := range req.Data.Education {
+			blocks = append(blocks, entryBlock{
+				index: index,
+				id:    edu.ID,
+				label: firstNonEmpty(edu.Institution, edu.Degree),
+				start: edu.StartDate,
+				end:   edu.EndDate,
+				fields: map[string]string{
+					"institution": edu.Institution,
+					"location":    edu.Location,
+					"degree":      educationDegreeLine(edu),
+					"dates":       formatDateRange(edu.StartDate, edu.EndDate, dateStyle),
+					"honors":      prefixed("Honors: ", edu.Honors),
+					"coursework":  prefixed("Relevant Coursework: ", strings.Join(nonBlank(edu.RelevantCoursework), ", ")),
+				},
+				bullets: filter.Bullets(edu.Bullets),
+			})
+		}
+		sortEntryBlocks(blocks, req.Settings.SortEntries)
+		renderEntrySection(pdf, section, title, blocks, fontFamily, fontSize, layout, recorder)
+
+	case models.SectionExperience:
+		blocks := make([]entryBlock, 0, len(req.Data.Experience))
+		for index, exp := range req.Data.Experience {
+			if !filter.Includes(exp.Tags) {
+				continue
+			}
+			if len(exp.Roles) > 0 {
+				if exp.Roles = filter.Roles(exp.Roles); len(exp.Roles) == 0 {
+					continue
+				}
+				block := groupedExperienceBlock(exp, dateStyle, req.Settings.SortEntries)
+				block.index = index
+				blocks = append(blocks, block)
+				continue
+			}
+			blocks = append(blocks, entryBlock{
+				index: index,
+				id:    exp.ID,
+				label: firstNonEmpty(exp.Role, exp.Company),
+				start: exp.StartDate,
+				end:   exp.EndDate,
+				fields: map[string]string{
+					"role":     exp.Role,
+					"company":  exp.Company,
+					"location": exp.Location,
+					"dates":    formatDateRange(exp.StartDate, exp.EndDate, dateStyle),
+				},
+				links:   companyLinks(exp.URL),
+				bullets: filter.Bullets(exp.Bullets),
+			})
+		}
+		sortEntryBlocks(blocks, req.Settings.SortEntries)
+		renderEntrySection(pdf, section, title, blocks, fontFamily, fontSize, layout, recorder)
+
+	case models.SectionProjects:
+		blocks := make([]entryBlock, 0, len(req.Data.Projects))
+		for index, project := range req.Data.Projects {
+			if !filter.Includes(project.Tags) {
+				continue
+			}
+			blocks = append(blocks, entryBlock{
+				index: index,
+				id:    project.ID,
+				label: project.Name,
+				start: project.StartDate,
+				end:   project.EndDate,
+				fields: map[string]string{
+					"name":      project.Name,
+					"techStack": project.TechStack,
+					"dates":     formatDateRange(project.StartDate, project.EndDate, dateStyle),
+				},
+				links:   projectLinks(project.URL, project.RepoURL),
+				bullets: filter.Bullets(project.Bullets),
+			})
+		}
+		sortEntryBlocks(blocks, req.Settings.SortEntries)
+		renderEntrySection(pdf, section, title, blocks, fontFamily, fontSize, layout, recorder)
+
+	case models.SectionTechnicalSkills:
+		if !req.Data.TechnicalSkills.HasSkills() {
+			return
+		}
+		page, y := addSectionTitle(pdf, fontFamily, fontSize, title, layout.lineHeight, layout)
+		recorder.beginSection(section, title, page, y)
+		renderTechnicalSkills(pdf, fontFamily, fontSize, req.Data.TechnicalSkills, layout)
+
+	case models.SectionCustom:
+		for _, custom := range req.Data.CustomSections {
+			if strings.TrimSpace(custom.Title) == "" {
+				continue
+			}
+			blocks := make([]entryBlock, 0, len(custom.Entries))
+			for index, entry := range custom.Entries {
+				blocks = append(blocks, entryBlock{
+					index: index,
+					id:    entry.ID,
+					label: firstNonEmpty(entry.Primary, entry.Secondary),
+					fields: map[string]string{
+						"primary":   entry.Primary,
+						"secondary": entry.Secondary,
+						"date":      formatDate(entry.Date, dateStyle),
+						"location":  entry.Location,
+					},
+					bullets: filter.Bullets(entry.Bullets),
+				})
+			}
+			renderEntrySection(pdf, section, strings.TrimSpace(custom.Title), blocks, fontFamily, fontSize, layout, recorder)
+		}
+	}
+}
+
+// renderEntrySection draws a titled section of entries using the template rows for section.
+func renderEntrySection(pdf *fpdf.Fpdf, section string, title string, blocks []entryBlock, fontFamily string, fontSize float64, layout layoutConfig, recorder *layoutRecorder) {
+	if len(blocks) == 0 {
+		return
+	}
+
+	rows := layout.entryRows[section]
+	page, y := addSectionTitle(pdf, fontFamily, fontSize, title, measureEntryHead(pdf, fontFamily, fontSize, rows, blocks[0], layout), layout)
+	recorder.beginSection(section, title, page, y)
+
+	for _, block := range blocks {
+		keepTogether(pdf, math.Max(layout.lineHeight, measureEntryHead(pdf, fontFamily, fontSize, rows, block, layout)), layout)
+		recorder.beginEntry(block.index, block.id, strings.TrimSpace(block.label), pdf.PageNo(), pdf.GetY())
+
+		if len(block.roles) > 0 {
+			writeGroupedEntry(pdf, fontFamily, fontSize, block, layout)
+		} else {
+			writeEntryRows(pdf, fontFamily, fontSize, rows, block.fields, block.links, layout)
+			writeBullets(pdf, fontFamily, fontSize, block.bullets, layout)
+		}
+
+		recorder.endEntry(pdf.PageNo(), pdf.GetY())
+		pdf.Ln(layout.entrySpacing)
+	}
+}
+
+// educationDegreeLine joins the degree, minor, and GPA shown on an education entry's
+// degree row, such as "B.S. Computer Science, Minor in Mathematics; GPA: 3.8/4.0".
+func educationDegreeLine(edu models.EducationEntry) string {
+	degree := strings.TrimSpace(edu.Degree)
+	if minor := strings.TrimSpace(edu.Minor); minor != "" {
+		if degree != "" {
+			degree += ", "
+		}
+		degree += "Minor in " + minor
+	}
+	gpa := strings.TrimSpace(edu.GPA)
+	if gpa == "" {
+		return degree
+	}
+	if scale := strings.TrimSpace(edu.GPAScale); scale != "" {
+		gpa += "/" + scale
+	}
+	if degree == "" {
+		return "GPA: " + gpa
+	}
+	return degree + "; GPA: " + gpa
+}
+
+// prefixed returns value after prefix, or "" when value is blank.
+func prefixed(prefix string, value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return prefix + strings.TrimSpace(value)
+}
+
+// nonBlank returns values trimmed, without blank entries.
+func nonBlank(values []string) []string {
+	var kept []string
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			kept = append(kept, trimmed)
+		}
+	}
+	return kept
+}
+
+// groupedExperienceBlock reduces an experience entry with several roles to one block
+// headed by its company. Roles are sorted by order, and the block sorts by its first role.
+func groupedExperienceBlock(exp models.ExperienceEntry, dateStyle string, order string) entryBlock {
+	roles := make([]roleBlock, 0, len(exp.Roles))
+	for _, role := range exp.Roles {
+		roles = append(roles, roleBlock{
+			start: role.StartDate,
+			end:   role.EndDate,
+			fields: map[string]string{
+				"role":  role.Role,
+				"dates": formatDateRange(role.StartDate, role.EndDate, dateStyle),
+			},
+			bullets: role.Bullets,
+		})
+	}
+	sortRoleBlocks(roles, order)
+	return entryBlock{
+		id:    exp.ID,
+		label: firstNonEmpty(exp.Company, exp.Roles[0].Role),
+		start: roles[0].start,
+		end:   roles[0].end,
+		fields: map[string]string{
+			"company":  exp.Company,
+			"location": exp.Location,
+		},
+		links: companyLinks(exp.URL),
+		roles: roles,
+	}
+}
+
+// writeGroupedEntry draws the company rows of block once, followed by each role's rows
+// and bullets. Every role after the first is kept with its first bullets.
+func writeGroupedEntry(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, block entryBlock, layout layoutConfig) {
+	headRows, roleRows := groupRows(layout)
+	writeEntryRows(pdf, fontFamily, fontSize, headRows, block.fields, block.links, layout)
+	for index, role := range block.roles {
+		if index > 0 {
+			pdf.Ln(layout.entrySpacing / 2)
+			keepTogether(pdf, math.Max(layout.lineHeight, measureRowsAndBullets(pdf, fontFamily, fontSize, roleRows, role.fields, nil, role.bullets, layout)), layout)
+		}
+		writeEntryRows(pdf, fontFamily, fontSize, roleRows, role.fields, nil, layout)
+		writeBullets(pdf, fontFamily, fontSize, role.bullets, layout)
+	}
+}
+
+// groupRows returns the company and role rows of grouped entries, stacked in narrow
+// columns.
+func groupRows(layout layoutConfig) ([]rowSpec, []rowSpec) {
+	if layout.narrow {
+		return stackRows(groupHeadRows), stackRows(groupRoleRows)
+	}
+	return groupHeadRows, groupRoleRows
+}
+
+func writeBullets(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, bullets []models.Bullet, layout layoutConfig) {
+	for _, bullet := range bullets {
+		writeBullet(pdf, fontFamily, fontSize, bullet, 0, layout)
+	}
+}
+
+// resolveSectionOrder applies the user's section order and visibility on top of the
+// template order. Sections the user did not order keep their template position after
+// the ordered ones; sections neither order mentions come last.
+func resolveSectionOrder(templateOrder []string, settings models.ResumeSetting) []string {
+	order := make([]string, 0, len(templateOrder))
+	seen := make(map[string]bool, len(templateOrder))
+	appendSection := func(section string) {
+		if seen[section] || !models.IsSectionKey(section) {
+			return
+		}
+		seen[section] = true
+		if visible, ok := settings.SectionVisibility[section]; ok && !visible {
+			return
+		}
+		order = append(order, section)
+	}
+
+	for _, section := range settings.SectionOrder {
+		appendSection(strings.TrimSpace(section))
+	}
+	for _, section := range templateOrder {
+		appendSection(section)
+	}
+	for _, section := range models.SectionKeys {
+		appendSection(section)
+	}
+
+	return order
+}
+
+// writeEntryRows renders an entry's header rows as composed by the template. A row whose
+// left field has a link is set from linked spans.
+func writeEntryRows(pdf *fpdf.Fpdf, fontFamily string, fontSize float64, rows []rowSpec, fields map[string]string, links map[string]fieldLink, layout layoutConfig) {
+	for _, row := range rows {
+		if spans := linkSpans(fields[row.left], row.style, links[row.left]); spans != nil {
+			if row.right == "" {
+				writeInlineText(pdf, fontFamily, fontSize, spans, layout)
+			} else {
+				writeLinkedTwoColumnRow(pdf, fontFamily, fontSize, row.style, spans, fields[row.right], layout)
+			}
+			continue
+		}
+		if row.right == "" {
+			writeWrappedText(pdf, fontFamily, row.style, fontSize, fields[row.left], layout)
+			continue
+		}
+		writeStyledTwoColumnRow(pdf, fontFamily, fontSize, row.style, fields[row.left], fields[row.right], layout)
+	}
+}
+
+func applyTitleCase(title string, titleCase string) string {
+	switch titleCase {
+	case titleCaseUpper:
+		return strings.ToUpper(title)
+	case titleCaseTitle:
+		words := strings.Fields(title)
+		for i, word := range words {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			words[i] = string(runes)
+		}
+		return strings.Join(words, " ")
+	default:
+		return title
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
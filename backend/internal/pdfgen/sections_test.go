@@ -0,0 +1,172 @@
+package pdfgen
+
+import (
+	"reflect"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestResolveSectionOrderAppliesUserOrderAndVisibility(t *testing.T) {
+	cases := []struct {
+		name          string
+		templateOrder []string
+		settings      models.ResumeSetting
+		expected      []string
+	}{
+		{
+			name:     "template order by default",
+			settings: models.ResumeSetting{},
+			expected: []string{"education", "experience", "projects", "technicalSkills", "customSections"},
+		},
+		{
+			name:     "experience first keeps remaining template order",
+			settings: models.ResumeSetting{SectionOrder: []string{"experience"}},
+			expected: []string{"experience", "education", "projects", "technicalSkills", "customSections"},
+		},
+		{
+			name: "hidden sections are dropped",
+			settings: models.ResumeSetting{
+				SectionOrder:      []string{"technicalSkills", "experience"},
+				SectionVisibility: map[string]bool{"projects": false, "education": true},
+			},
+			expected: []string{"technicalSkills", "experience", "education", "customSections"},
+		},
+		{
+			name:     "unknown and repeated keys are ignored",
+			settings: models.ResumeSetting{SectionOrder: []string{"hobbies", "projects", "projects"}},
+			expected: []string{"projects", "education", "experience", "technicalSkills", "customSections"},
+		},
+		{
+			name:          "sections missing from the template order come last",
+			templateOrder: []string{"experience", "education"},
+			settings:      models.ResumeSetting{},
+			expected:      []string{"experience", "education", "projects", "technicalSkills", "customSections"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			templateOrder := tc.templateOrder
+			if templateOrder == nil {
+				templateOrder = defaultLayout().sectionOrder
+			}
+			actual := resolveSectionOrder(templateOrder, tc.settings)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected order %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func groupedRolesRequest(grouped bool) models.GeneratePDFRequest {
+	roles := []models.ExperienceRole{
+		{Role: "Senior Engineer", StartDate: "2022", EndDate: "Present", Bullets: []models.Bullet{{Text: "Led the storage team."}}},
+		{Role: "Engineer", StartDate: "2019", EndDate: "2022", Bullets: []models.Bullet{{Text: "Built the query planner."}}},
+	}
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium"},
+	}
+	if grouped {
+		req.Data.Experience = []models.ExperienceEntry{{ID: "exp-1", Company: "Engines Ltd", Location: "London", Roles: roles}}
+		return req
+	}
+	for _, role := range roles {
+		req.Data.Experience = append(req.Data.Experience, models.ExperienceEntry{
+			Company:   "Engines Ltd",
+			Location:  "London",
+			Role:      role.Role,
+			StartDate: role.StartDate,
+			EndDate:   role.EndDate,
+			Bullets:   role.Bullets,
+		})
+	}
+	return req
+}
+
+func TestGroupedExperienceShowsCompanyOnce(t *testing.T) {
+	grouped, err := Generator{}.Layout(groupedRolesRequest(true))
+	if err != nil {
+		t.Fatalf("layout grouped: %v", err)
+	}
+	flat, err := Generator{}.Layout(groupedRolesRequest(false))
+	if err != nil {
+		t.Fatalf("layout flat: %v", err)
+	}
+
+	entries := grouped.Sections[0].Entries
+	if len(entries) != 1 || entries[0].Label != "Engines Ltd" || entries[0].ID != "exp-1" {
+		t.Fatalf("expected one grouped entry labelled by company, got %+v", entries)
+	}
+
+	flatEntries := flat.Sections[0].Entries
+	groupedHeight := entries[0].EndY - entries[0].Y
+	flatHeight := flatEntries[len(flatEntries)-1].EndY - flatEntries[0].Y
+	if groupedHeight >= flatHeight {
+		t.Fatalf("expected the grouped entry to be shorter than repeated entries, got %v vs %v", groupedHeight, flatHeight)
+	}
+}
+
+func TestGroupRowsStackInNarrowColumns(t *testing.T) {
+	layout := defaultLayout()
+	head, role := groupRows(layout)
+	if len(head) != 1 || len(role) != 1 {
+		t.Fatalf("expected side-by-side rows, got %+v and %+v", head, role)
+	}
+
+	layout.narrow = true
+	head, role = groupRows(layout)
+	if len(head) != 2 || len(role) != 2 || head[0].right != "" || role[0].right != "" {
+		t.Fatalf("expected stacked rows in a narrow column, got %+v and %+v", head, role)
+	}
+}
+
+func TestEducationDegreeLine(t *testing.T) {
+	tests := []struct {
+		edu  models.EducationEntry
+		want string
+	}{
+		{models.EducationEntry{Degree: "B.S. Computer Science"}, "B.S. Computer Science"},
+		{models.EducationEntry{Degree: "B.S. Computer Science", Minor: "Mathematics", GPA: "3.85", GPAScale: "4.0"}, "B.S. Computer Science, Minor in Mathematics; GPA: 3.85/4.0"},
+		{models.EducationEntry{Degree: "B.Tech", GPA: " 8.7 "}, "B.Tech; GPA: 8.7"},
+		{models.EducationEntry{Minor: "Music", GPA: "3.9"}, "Minor in Music; GPA: 3.9"},
+	}
+	for _, tt := range tests {
+		if got := educationDegreeLine(tt.edu); got != tt.want {
+			t.Fatalf("educationDegreeLine(%+v) = %q, want %q", tt.edu, got, tt.want)
+		}
+	}
+}
+
+func TestEducationDetailRowsRenderOnlyWhenSet(t *testing.T) {
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Education: []models.EducationEntry{
+				{Institution: "University of London", Degree: "B.S. Mathematics"},
+				{
+					Institution:        "University of London",
+					Degree:             "B.S. Mathematics",
+					Honors:             "First Class Honours",
+					RelevantCoursework: []string{"Analysis", " ", "Algebra"},
+				},
+			},
+		},
+		Settings: models.ResumeSetting{FontSize: "medium", FontFamily: "times"},
+	}
+	report, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	entries := report.Sections[0].Entries
+	plain := entries[0].EndY - entries[0].Y
+	detailed := entries[1].EndY - entries[1].Y
+	lineHeight := defaultLayout().lineHeight
+	if diff := detailed - plain; diff < 2*lineHeight-0.01 || diff > 2*lineHeight+0.01 {
+		t.Fatalf("expected honors and coursework to add two lines (%.2fmm), got %.2fmm", 2*lineHeight, diff)
+	}
+}
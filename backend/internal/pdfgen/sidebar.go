@@ -0,0 +1,212 @@
+package pdfgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+	"resume_maker/backend/internal/richtext"
+)
+
+// sidebarLayout places contact details, links, and the listed sections in a narrow left
+// column beside the main column. A zero width means the template has no sidebar.
+type sidebarLayout struct {
+	width    float64
+	gutter   float64
+	sections []string
+}
+
+// columnCursor is where a column continues drawing.
+type columnCursor struct {
+	page int
+	y    float64
+}
+
+func (c columnCursor) after(other columnCursor) bool {
+	return c.page > other.page || (c.page == other.page && c.y > other.y)
+}
+
+// renderSidebar draws a full-width header, then flows the sidebar and main columns
+// independently from the same starting point. Each column breaks onto the next page on
+// its own, reusing pages the other column has already added.
+func renderSidebar(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, layout layoutConfig, recorder *layoutRecorder) error {
+	pdf.SetMargins(layout.leftMargin, layout.topMargin, layout.rightMargin)
+	pdf.SetAutoPageBreak(true, layout.bottomMargin)
+	pdf.SetAcceptPageBreakFunc(func() bool {
+		if pdf.PageNo() < pdf.PageCount() {
+			x := pdf.GetX()
+			movePage(pdf, pdf.PageNo()+1)
+			pdf.SetXY(x, layout.topMargin)
+			return false
+		}
+		return true
+	})
+	setTextColor(pdf, layout.theme.text)
+	pdf.AddPage()
+
+	fontFamily := mapFont(req.Settings.FontFamily)
+	fontSize := mapFontSize(req.Settings.FontSize) + layout.fontSizeOffset
+
+	if err := renderSidebarHeader(pdf, req, fontFamily, fontSize, layout, recorder); err != nil {
+		return fmt.Errorf("render header: %w", err)
+	}
+	start := columnCursor{page: pdf.PageNo(), y: pdf.GetY()}
+
+	side, main := sidebarColumns(layout)
+	var sideSections, mainSections []string
+	for _, section := range resolveSectionOrder(layout.sectionOrder, req.Settings) {
+		if containsString(layout.sidebar.sections, section) {
+			sideSections = append(sideSections, section)
+		} else {
+			mainSections = append(mainSections, section)
+		}
+	}
+
+	enterColumn(pdf, side, start)
+	info := req.Data.PersonalInfo
+	options := contactOptionsFrom(req.Settings)
+	renderSidebarTokens(pdf, "Contact", buildHeaderContactTokens(models.PersonalInfo{Phone: info.Phone, Email: info.Email}, options), fontFamily, fontSize, side)
+	renderSidebarTokens(pdf, "Links", buildHeaderContactTokens(models.PersonalInfo{
+		LinkedIn:   info.LinkedIn,
+		GitHub:     info.GitHub,
+		Website:    info.Website,
+		OtherLinks: info.OtherLinks,
+	}, options), fontFamily, fontSize, side)
+	for _, section := range sideSections {
+		renderSection(pdf, req, section, fontFamily, fontSize, side, recorder)
+	}
+	sideEnd := columnCursor{page: pdf.PageNo(), y: pdf.GetY()}
+
+	enterColumn(pdf, main, start)
+	renderSummary(pdf, info.Summary, fontFamily, fontSize, main)
+	for _, section := range mainSections {
+		renderSection(pdf, req, section, fontFamily, fontSize, main, recorder)
+	}
+	mainEnd := columnCursor{page: pdf.PageNo(), y: pdf.GetY()}
+
+	drawColumnRules(pdf, layout, start)
+
+	end := mainEnd
+	if sideEnd.after(mainEnd) {
+		end = sideEnd
+	}
+	enterColumn(pdf, layout, end)
+	return nil
+}
+
+// sidebarColumns splits layout into the sidebar column and the main column. The sidebar
+// stacks two-column entry rows and skill labels because it is too narrow to set them side
+// by side.
+func sidebarColumns(layout layoutConfig) (layoutConfig, layoutConfig) {
+	side := layout
+	side.rightMargin = layout.page.width - layout.leftMargin - layout.sidebar.width
+	side.narrow = true
+	side.entryRows = make(map[string][]rowSpec, len(layout.entryRows))
+	for section, rows := range layout.entryRows {
+		side.entryRows[section] = stackRows(rows)
+	}
+
+	main := layout
+	main.leftMargin = layout.leftMargin + layout.sidebar.width + layout.sidebar.gutter
+	return side, main
+}
+
+// stackRows turns each two-column row into two full-width rows.
+func stackRows(rows []rowSpec) []rowSpec {
+	stacked := make([]rowSpec, 0, len(rows)*2)
+	for _, row := range rows {
+		stacked = append(stacked, rowSpec{left: row.left, style: row.style})
+		if row.right != "" {
+			stacked = append(stacked, rowSpec{left: row.right})
+		}
+	}
+	return stacked
+}
+
+// enterColumn moves the cursor to at and confines text to column's margins.
+func enterColumn(pdf *fpdf.Fpdf, column layoutConfig, at columnCursor) {
+	movePage(pdf, at.page)
+	pdf.SetLeftMargin(column.leftMargin)
+	pdf.SetRightMargin(column.rightMargin)
+	pdf.SetXY(column.leftMargin, at.y)
+}
+
+func renderSidebarHeader(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, fontFamily string, fontSize float64, layout layoutConfig, recorder *layoutRecorder) error {
+	photoReservedWidth := 0.0
+	if req.Settings.ShowPhoto && strings.TrimSpace(req.Photo) != "" {
+		photoReservedWidth = 30
+		if err := renderHeaderPhoto(pdf, req.Photo, layout.page.width-layout.rightMargin-headerPhotoDiameter, layout); err != nil {
+			return err
+		}
+	}
+
+	textLayout := layout
+	textLayout.rightMargin += photoReservedWidth
+
+	pdf.SetFont(fontFamily, "B", fontSize+layout.nameSizeDelta)
+	fullName := strings.TrimSpace(req.Data.PersonalInfo.FirstName + " " + req.Data.PersonalInfo.LastName)
+	recorder.checkOverflow("data.personalInfo", pdf.GetStringWidth(fullName), textLayout.contentWidth(), nameOverflowMessage)
+	pdf.SetX(layout.leftMargin)
+	setTextColor(pdf, layout.theme.accent)
+	pdf.CellFormat(textLayout.contentWidth(), 8, fullName, "", 1, "L", false, 0, "")
+	setTextColor(pdf, layout.theme.text)
+
+	if headline := strings.TrimSpace(req.Data.PersonalInfo.Headline); headline != "" {
+		writeWrappedTextAligned(pdf, fontFamily, "I", fontSize+1, headline, "L", textLayout)
+	}
+
+	if photoReservedWidth > 0 && pdf.GetY() < layout.topMargin+photoReservedWidth {
+		pdf.SetY(layout.topMargin + photoReservedWidth)
+	}
+	pdf.Ln(2)
+	if layout.divider != dividerNone {
+		y := pdf.GetY()
+		setDrawColor(pdf, layout.theme.rule)
+		pdf.Line(layout.leftMargin, y, layout.page.width-layout.rightMargin, y)
+		setDrawColor(pdf, black)
+	}
+	pdf.Ln(layout.sectionSpacing + 2)
+	return nil
+}
+
+// renderSidebarTokens draws a titled list of contact tokens, one per line. Token text is
+// indented past its icon so wrapped lines stay aligned.
+func renderSidebarTokens(pdf *fpdf.Fpdf, title string, tokens []contactToken, fontFamily string, fontSize float64, layout layoutConfig) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	addSectionTitle(pdf, fontFamily, fontSize, title, layout.lineHeight, layout)
+	for _, token := range tokens {
+		textLayout := layout
+		if token.icon != "" {
+			ensureSpace(pdf, layout.lineHeight, layout)
+			pdf.SetX(layout.leftMargin)
+			drawTokenIcon(pdf, token, fontFamily, fontSize, layout)
+			textLayout.leftMargin = pdf.GetX()
+		}
+		writeInlineText(pdf, fontFamily, fontSize, []richtext.Span{{Text: token.text, URL: token.url}}, textLayout)
+	}
+	pdf.Ln(layout.entrySpacing)
+}
+
+// drawColumnRules draws the vertical rule between the columns on every page.
+func drawColumnRules(pdf *fpdf.Fpdf, layout layoutConfig, start columnCursor) {
+	if layout.divider == dividerNone || layout.sidebar.gutter <= 0 {
+		return
+	}
+
+	x := layout.leftMargin + layout.sidebar.width + layout.sidebar.gutter/2
+	for page := start.page; page <= pdf.PageCount(); page++ {
+		top := layout.topMargin
+		if page == start.page {
+			top = start.y
+		}
+		movePage(pdf, page)
+		setDrawColor(pdf, layout.theme.rule)
+		pdf.Line(x, top, x, layout.page.height-layout.bottomMargin)
+		setDrawColor(pdf, black)
+	}
+}
@@ -0,0 +1,107 @@
+package pdfgen
+
+import (
+	"strings"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func sidebarRequest(bulletCount int) models.GeneratePDFRequest {
+	req := fitRequest(bulletCount)
+	req.TemplateID = "sidebar"
+	req.Data.PersonalInfo.LinkedIn = "linkedin.com/in/ada"
+	req.Data.TechnicalSkills = models.TechnicalSkills{
+		{Label: "Languages", Skills: []string{"Go", "Python"}},
+		{Label: "Developer Tools", Skills: []string{"Git", "Docker"}},
+	}
+	return req
+}
+
+func TestSidebarTemplateFlowsColumnsFromTheSameStart(t *testing.T) {
+	report, err := Generator{}.Layout(sidebarRequest(40))
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	if report.Pages != 2 {
+		t.Fatalf("expected 2 pages, got %d", report.Pages)
+	}
+	if len(report.Sections) != 2 {
+		t.Fatalf("expected skills and experience placements, got %+v", report.Sections)
+	}
+
+	skills, experience := report.Sections[0], report.Sections[1]
+	if skills.Key != models.SectionTechnicalSkills || experience.Key != models.SectionExperience {
+		t.Fatalf("expected sidebar skills before main experience, got %s then %s", skills.Key, experience.Key)
+	}
+	if skills.Page != 1 || experience.Page != 1 {
+		t.Fatalf("expected both columns to start on page 1, got skills=%d experience=%d", skills.Page, experience.Page)
+	}
+	// The sidebar starts with contact and links, so skills sit below the main column start.
+	if experience.Y >= skills.Y {
+		t.Fatalf("expected experience to start above sidebar skills, got experience=%v skills=%v", experience.Y, skills.Y)
+	}
+	if entry := experience.Entries[0]; entry.EndPage != 2 {
+		t.Fatalf("expected experience to continue on page 2, got %+v", entry)
+	}
+}
+
+func TestSidebarColumnBreaksOntoExistingPage(t *testing.T) {
+	dir := t.TempDir()
+	writeDefinition(t, dir, "tall-sidebar.json", `{
+		"id": "tall-sidebar",
+		"name": "Tall Sidebar",
+		"sidebar": {"width": 60, "gutter": 6, "sections": ["customSections", "experience"]}
+	}`)
+	t.Cleanup(func() { unregisterTemplate("tall-sidebar") })
+	if err := LoadTemplateDir(dir); err != nil {
+		t.Fatalf("load template dir: %v", err)
+	}
+
+	req := fitRequest(70)
+	req.TemplateID = "tall-sidebar"
+	req.Data.Projects = []models.ProjectEntry{{ID: "proj-1", Name: "Difference Engine", Bullets: []models.Bullet{{Text: "Short main column."}}}}
+
+	report, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	if report.Pages < 3 {
+		t.Fatalf("expected the sidebar to run over several pages, got %d", report.Pages)
+	}
+	var projects models.SectionPlacement
+	for _, section := range report.Sections {
+		if section.Key == models.SectionProjects {
+			projects = section
+		}
+	}
+	if projects.Page != 1 {
+		t.Fatalf("expected main column to start on page 1 after the sidebar broke, got %+v", projects)
+	}
+	if report.RemainingHeight >= report.UsableHeight {
+		t.Fatalf("expected the report cursor to follow the longer sidebar, got remaining=%v", report.RemainingHeight)
+	}
+
+	pdfBytes, err := Generator{}.Generate(req)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if pages := strings.Count(string(pdfBytes), "/Type /Page\n"); pages != report.Pages {
+		t.Fatalf("expected %d page objects, got %d", report.Pages, pages)
+	}
+}
+
+func TestStackRowsSplitsTwoColumnRows(t *testing.T) {
+	rows := stackRows([]rowSpec{{left: "role", right: "dates", style: "B"}, {left: "techStack", style: "I"}})
+	want := []rowSpec{{left: "role", style: "B"}, {left: "dates"}, {left: "techStack", style: "I"}}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for index := range want {
+		if rows[index] != want[index] {
+			t.Fatalf("row %d: expected %+v, got %+v", index, want[index], rows[index])
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package pdfgen
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+)
+
+// DefaultTemplateID is used when a request does not name a template.
+const DefaultTemplateID = "classic"
+
+// Template describes a registered resume layout.
+type Template struct {
+	ID          string
+	Name        string
+	Description string
+	layout      layoutConfig
+	render      renderFunc
+}
+
+// renderFunc draws a resume onto pdf, which already has fonts registered but no pages,
+// reporting section and entry positions to recorder.
+type renderFunc func(pdf *fpdf.Fpdf, req models.GeneratePDFRequest, layout layoutConfig, recorder *layoutRecorder) error
+
+var (
+	templatesMu sync.RWMutex
+	templates   []Template
+)
+
+func init() {
+	if err := loadBuiltinTemplates(); err != nil {
+		panic(err)
+	}
+}
+
+// Templates returns every registered template in registration order.
+func Templates() []Template {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+
+	result := make([]Template, len(templates))
+	copy(result, templates)
+	return result
+}
+
+// LookupTemplate resolves id to a registered template; an empty id selects the default.
+func LookupTemplate(id string) (Template, bool) {
+	key := normalizeTemplateID(id)
+	if key == "" {
+		key = DefaultTemplateID
+	}
+
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+
+	for _, template := range templates {
+		if template.ID == key {
+			return template, true
+		}
+	}
+	return Template{}, false
+}
+
+func registerTemplate(template Template) error {
+	template.ID = normalizeTemplateID(template.ID)
+	if template.ID == "" {
+		return fmt.Errorf("template id must not be empty")
+	}
+	if template.render == nil {
+		return fmt.Errorf("template %s has no render function", template.ID)
+	}
+
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+
+	for _, existing := range templates {
+		if existing.ID == template.ID {
+			return fmt.Errorf("template %s is already registered", template.ID)
+		}
+	}
+	templates = append(templates, template)
+	return nil
+}
+
+func normalizeTemplateID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
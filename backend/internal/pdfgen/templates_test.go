@@ -0,0 +1,91 @@
+package pdfgen
+
+import (
+	"bytes"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestBuiltinTemplatesAreRegistered(t *testing.T) {
+	templates := Templates()
+	if len(templates) < 3 {
+		t.Fatalf("expected at least 3 registered templates, got %d", len(templates))
+	}
+	if templates[0].ID != DefaultTemplateID {
+		t.Fatalf("expected default template %q to be registered first, got %q", DefaultTemplateID, templates[0].ID)
+	}
+
+	for _, id := range []string{"classic", "modern", "compact", "sidebar"} {
+		template, ok := LookupTemplate(id)
+		if !ok {
+			t.Fatalf("expected template %q to be registered", id)
+		}
+		if template.Name == "" || template.Description == "" {
+			t.Fatalf("expected template %q to have a name and description", id)
+		}
+	}
+}
+
+func TestLookupTemplateDefaultsAndNormalizes(t *testing.T) {
+	template, ok := LookupTemplate("")
+	if !ok || template.ID != DefaultTemplateID {
+		t.Fatalf("expected empty id to resolve to %q, got %q (ok=%v)", DefaultTemplateID, template.ID, ok)
+	}
+
+	template, ok = LookupTemplate("  Modern ")
+	if !ok || template.ID != "modern" {
+		t.Fatalf("expected padded mixed-case id to resolve to modern, got %q (ok=%v)", template.ID, ok)
+	}
+
+	if _, ok := LookupTemplate("does-not-exist"); ok {
+		t.Fatal("expected unknown template lookup to fail")
+	}
+}
+
+func TestRegisterTemplateRejectsDuplicatesAndMissingRender(t *testing.T) {
+	if err := registerTemplate(Template{ID: "classic", layout: defaultLayout(), render: renderSingleColumn}); err == nil {
+		t.Fatal("expected duplicate template id to be rejected")
+	}
+	if err := registerTemplate(Template{ID: "no-render"}); err == nil {
+		t.Fatal("expected template without render function to be rejected")
+	}
+	if err := registerTemplate(Template{ID: "  ", layout: defaultLayout(), render: renderSingleColumn}); err == nil {
+		t.Fatal("expected blank template id to be rejected")
+	}
+}
+
+func TestGeneratePDFDiffersByTemplate(t *testing.T) {
+	generator := Generator{}
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Experience: []models.ExperienceEntry{
+				{Role: "Analyst", Company: "Engines Ltd", Bullets: []models.Bullet{{Text: "Wrote the first program."}}},
+			},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium"},
+	}
+
+	outputs := make(map[string][]byte)
+	for _, id := range []string{"classic", "modern", "compact"} {
+		req.TemplateID = id
+		pdfBytes, err := generator.Generate(req)
+		if err != nil {
+			t.Fatalf("generate(%s): %v", id, err)
+		}
+		outputs[id] = pdfBytes
+	}
+
+	if bytes.Equal(outputs["classic"], outputs["modern"]) {
+		t.Fatal("expected classic and modern PDFs to differ")
+	}
+	if bytes.Equal(outputs["classic"], outputs["compact"]) {
+		t.Fatal("expected classic and compact PDFs to differ")
+	}
+
+	req.TemplateID = "does-not-exist"
+	if _, err := generator.Generate(req); err == nil {
+		t.Fatal("expected unknown template to fail generation")
+	}
+}
@@ -0,0 +1,68 @@
+package pdfgen
+
+import (
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"resume_maker/backend/internal/models"
+)
+
+type rgbColor struct {
+	r int
+	g int
+	b int
+}
+
+// colorTheme holds the colors used for the name and section titles (accent), body text,
+// links, and section dividers plus the photo ring (rule).
+type colorTheme struct {
+	accent rgbColor
+	text   rgbColor
+	link   rgbColor
+	rule   rgbColor
+}
+
+var (
+	black     = rgbColor{0, 0, 0}
+	nearBlack = rgbColor{26, 26, 26}
+)
+
+var themePresets = map[string]colorTheme{
+	models.ThemeClassic:    {accent: black, text: black, link: rgbColor{47, 95, 121}, rule: black},
+	models.ThemeMonochrome: {accent: black, text: black, link: black, rule: black},
+	models.ThemeNavy:       {accent: rgbColor{31, 58, 95}, text: nearBlack, link: rgbColor{31, 58, 95}, rule: rgbColor{31, 58, 95}},
+	models.ThemeForest:     {accent: rgbColor{46, 94, 78}, text: nearBlack, link: rgbColor{46, 94, 78}, rule: rgbColor{46, 94, 78}},
+	models.ThemeBurgundy:   {accent: rgbColor{122, 31, 43}, text: nearBlack, link: rgbColor{122, 31, 43}, rule: rgbColor{122, 31, 43}},
+}
+
+// applyThemeSettings returns layout with the theme preset and color overrides from
+// settings. Unknown presets and malformed colors keep the template theme.
+func applyThemeSettings(layout layoutConfig, settings models.ResumeSetting) layoutConfig {
+	theme := settings.Theme
+	if theme == nil {
+		return layout
+	}
+	if preset, ok := themePresets[strings.ToLower(strings.TrimSpace(theme.Preset))]; ok {
+		layout.theme = preset
+	}
+	overrideColor(&layout.theme.accent, theme.Accent)
+	overrideColor(&layout.theme.text, theme.Text)
+	overrideColor(&layout.theme.link, theme.Link)
+	overrideColor(&layout.theme.rule, theme.Rule)
+	return layout
+}
+
+func overrideColor(target *rgbColor, hex string) {
+	if r, g, b, ok := models.ParseHexColor(hex); ok {
+		*target = rgbColor{r, g, b}
+	}
+}
+
+func setTextColor(pdf *fpdf.Fpdf, color rgbColor) {
+	pdf.SetTextColor(color.r, color.g, color.b)
+}
+
+func setDrawColor(pdf *fpdf.Fpdf, color rgbColor) {
+	pdf.SetDrawColor(color.r, color.g, color.b)
+}
@@ -0,0 +1,40 @@
+package pdfgen
+
+import (
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestApplyThemeSettings(t *testing.T) {
+	base := defaultLayout()
+
+	if got := applyThemeSettings(base, models.ResumeSetting{}); got.theme != themePresets[models.ThemeClassic] {
+		t.Fatalf("expected classic theme without settings, got %+v", got.theme)
+	}
+
+	mono := applyThemeSettings(base, models.ResumeSetting{Theme: &models.ThemeSetting{Preset: "Monochrome"}})
+	if mono.theme.link != black || mono.theme.accent != black || mono.theme.rule != black {
+		t.Fatalf("expected monochrome theme to be all black, got %+v", mono.theme)
+	}
+
+	custom := applyThemeSettings(base, models.ResumeSetting{Theme: &models.ThemeSetting{
+		Preset: models.ThemeNavy,
+		Accent: "#AA0010",
+		Rule:   "not-a-color",
+	}})
+	if custom.theme.accent != (rgbColor{170, 0, 16}) {
+		t.Fatalf("expected accent override, got %+v", custom.theme.accent)
+	}
+	if custom.theme.rule != themePresets[models.ThemeNavy].rule || custom.theme.link != themePresets[models.ThemeNavy].link {
+		t.Fatalf("expected navy colors where not overridden, got %+v", custom.theme)
+	}
+}
+
+func TestEveryThemePresetIsDefined(t *testing.T) {
+	for _, name := range models.ThemePresets {
+		if _, ok := themePresets[name]; !ok {
+			t.Fatalf("theme preset %q has no colors", name)
+		}
+	}
+}
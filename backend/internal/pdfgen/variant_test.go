@@ -0,0 +1,52 @@
+package pdfgen
+
+import (
+	"reflect"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestVariantLayoutRendersOnlyMatchingEntries(t *testing.T) {
+	req := models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace"},
+			Experience: []models.ExperienceEntry{
+				{Role: "Research Engineer", Company: "Engines Ltd", Tags: []string{"ml"}},
+				{Company: "Analytical Society", Roles: []models.ExperienceRole{
+					{Role: "Model Builder", Tags: []string{"ml"}},
+					{Role: "Platform Lead", Tags: []string{"backend"}},
+				}},
+				{Company: "Difference Works", Roles: []models.ExperienceRole{
+					{Role: "Statistician", Tags: []string{"ml"}},
+				}},
+			},
+			Projects: []models.ProjectEntry{
+				{Name: "Bernoulli Numbers", Tags: []string{"ml"}},
+				{Name: "Card Reader API", Tags: []string{"backend"}},
+				{Name: "Notes"},
+			},
+		},
+		Settings: models.ResumeSetting{FontFamily: "times", FontSize: "medium"},
+		Variant:  &models.ResumeVariant{Name: "Backend", IncludeTags: []string{"backend"}},
+	}
+
+	report, err := Generator{}.Layout(req)
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	indexes := map[string][]int{}
+	for _, section := range report.Sections {
+		for _, entry := range section.Entries {
+			indexes[section.Key] = append(indexes[section.Key], entry.Index)
+		}
+	}
+	want := map[string][]int{
+		models.SectionExperience: {1},
+		models.SectionProjects:   {1, 2},
+	}
+	if !reflect.DeepEqual(indexes, want) {
+		t.Fatalf("expected entries %v, got %v", want, indexes)
+	}
+}
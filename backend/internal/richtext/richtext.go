@@ -0,0 +1,247 @@
+// Package richtext parses the small inline markup accepted in bullet text:
+// **bold**, *italic*, and [link text](url). A backslash escapes any markup character.
+package richtext
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Span is a run of text sharing one style and link target.
+type Span struct {
+	Text   string
+	Bold   bool
+	Italic bool
+	URL    string
+}
+
+// SyntaxError reports malformed markup. Position counts characters from the start of
+// the text, starting at 1.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// HasMarkup reports whether text contains any character the parser treats specially.
+func HasMarkup(text string) bool {
+	return strings.ContainsAny(text, `*[\`)
+}
+
+// Parse splits text into styled spans. Square brackets that are not followed by a
+// (url) part are kept as literal text, and so is a * or ** that is never closed, as in
+// "Rated 4.9* on the App Store", or that has whitespace on the inner side, as in
+// "2 * 3 * 4". Only malformed links are errors.
+func Parse(text string) ([]Span, error) {
+	literal := map[int]bool{}
+	for {
+		p := parser{runes: []rune(text), literal: literal}
+		if err := p.parse(); err != nil {
+			return nil, err
+		}
+		// Each pass leaves at most the last opener unclosed; keep it as text and retry.
+		switch {
+		case p.bold:
+			literal[p.boldOpen] = true
+		case p.italic:
+			literal[p.italicOpen] = true
+		default:
+			return p.spans, nil
+		}
+	}
+}
+
+// Plain returns text with all markup removed, or text unchanged if it does not parse.
+func Plain(text string) string {
+	spans, err := Parse(text)
+	if err != nil {
+		return text
+	}
+
+	var builder strings.Builder
+	for _, span := range spans {
+		builder.WriteString(span.Text)
+	}
+	return builder.String()
+}
+
+type parser struct {
+	runes []rune
+	spans []Span
+	// literal holds the positions of emphasis markers that are drawn as text.
+	literal map[int]bool
+
+	current    strings.Builder
+	bold       bool
+	italic     bool
+	url        string
+	boldOpen   int
+	italicOpen int
+}
+
+func (p *parser) parse() error {
+	linkClose, linkEnd := -1, -1
+	for i := 0; i < len(p.runes); i++ {
+		r := p.runes[i]
+		switch {
+		case r == '\\' && i+1 < len(p.runes) && isMarkupRune(p.runes[i+1]):
+			i++
+			p.current.WriteRune(p.runes[i])
+		case r == '*' && i+1 < len(p.runes) && p.runes[i+1] == '*':
+			if p.literal[i] || !p.flanking(i, 2, !p.bold) {
+				p.current.WriteString("**")
+				i++
+				continue
+			}
+			p.flush()
+			p.bold = !p.bold
+			p.boldOpen = i
+			i++
+		case r == '*':
+			if p.literal[i] || !p.flanking(i, 1, !p.italic) {
+				p.current.WriteRune(r)
+				continue
+			}
+			p.flush()
+			p.italic = !p.italic
+			p.italicOpen = i
+		case r == '[' && p.url == "":
+			closeText, url, targetEnd, err := p.scanLink(i)
+			if err != nil {
+				return err
+			}
+			if closeText < 0 {
+				p.current.WriteRune(r)
+				continue
+			}
+			p.flush()
+			p.url = url
+			linkClose, linkEnd = closeText, targetEnd
+		case i == linkClose:
+			p.flush()
+			p.url = ""
+			i = linkEnd
+			linkClose, linkEnd = -1, -1
+		default:
+			p.current.WriteRune(r)
+		}
+	}
+	p.flush()
+	return nil
+}
+
+// scanLink looks for "](url)" after the "[" at open. It returns closeText < 0 when the
+// bracket does not start a link.
+func (p *parser) scanLink(open int) (closeText int, url string, end int, err error) {
+	closeText = -1
+	for i := open + 1; i < len(p.runes); i++ {
+		r := p.runes[i]
+		if r == '\\' {
+			i++
+			continue
+		}
+		if r == '[' {
+			return -1, "", 0, nil
+		}
+		if r == ']' {
+			closeText = i
+			break
+		}
+	}
+	if closeText < 0 || closeText+1 >= len(p.runes) || p.runes[closeText+1] != '(' {
+		return -1, "", 0, nil
+	}
+	if closeText == open+1 {
+		return 0, "", 0, &SyntaxError{Position: open + 1, Message: "link text must not be empty"}
+	}
+
+	// Parentheses inside the URL nest, as in .../wiki/Go_(programming_language).
+	end = -1
+	depth := 0
+	for i := closeText + 2; i < len(p.runes) && end < 0; i++ {
+		switch p.runes[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				end = i
+			}
+			depth--
+		}
+	}
+	if end < 0 {
+		return 0, "", 0, &SyntaxError{Position: closeText + 2, Message: "link is missing a closing )"}
+	}
+
+	url = strings.TrimSpace(string(p.runes[closeText+2 : end]))
+	if url == "" {
+		return 0, "", 0, &SyntaxError{Position: closeText + 2, Message: "link URL must not be empty"}
+	}
+	if strings.ContainsAny(url, " \t\n") {
+		return 0, "", 0, &SyntaxError{Position: closeText + 2, Message: "link URL must not contain spaces"}
+	}
+	if !hasAllowedScheme(url) {
+		return 0, "", 0, &SyntaxError{Position: closeText + 2, Message: "link URL must use http, https, or mailto, or be a host such as example.com"}
+	}
+	return closeText, url, end, nil
+}
+
+// flanking applies the CommonMark flanking rule to the size-rune marker at i: an
+// opener must be followed by a non-space character and a closer preceded by one, so
+// the stars in "2 * 3 * 4" stay text.
+func (p *parser) flanking(i int, size int, opener bool) bool {
+	if opener {
+		return i+size < len(p.runes) && !unicode.IsSpace(p.runes[i+size])
+	}
+	return i > 0 && !unicode.IsSpace(p.runes[i-1])
+}
+
+func (p *parser) flush() {
+	if p.current.Len() == 0 {
+		return
+	}
+	p.spans = append(p.spans, Span{Text: p.current.String(), Bold: p.bold, Italic: p.italic, URL: p.url})
+	p.current.Reset()
+}
+
+func isMarkupRune(r rune) bool {
+	switch r {
+	case '\\', '*', '[', ']', '(', ')':
+		return true
+	default:
+		return false
+	}
+}
+
+// hasAllowedScheme accepts explicit http, https, and mailto URLs as well as bare hosts
+// such as example.com, so javascript: and data: targets, scheme-relative //host URLs
+// and words such as "note" are rejected.
+func hasAllowedScheme(url string) bool {
+	lower := strings.ToLower(url)
+	for _, prefix := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return len(url) > len(prefix)
+		}
+	}
+	return isBareHost(url)
+}
+
+// isBareHost reports whether url starts with a dotted host name, optionally with a
+// port, as in example.com:8080/app.
+func isBareHost(url string) bool {
+	host := url
+	if end := strings.IndexAny(url, "/?#"); end >= 0 {
+		host = url[:end]
+	}
+	if name, port, ok := strings.Cut(host, ":"); ok {
+		if port == "" || strings.Trim(port, "0123456789") != "" {
+			return false
+		}
+		host = name
+	}
+	return strings.Contains(host, ".") && !strings.HasPrefix(host, ".") && !strings.HasSuffix(host, ".")
+}
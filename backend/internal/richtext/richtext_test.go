@@ -0,0 +1,163 @@
+package richtext
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Span
+	}{
+		{
+			name: "plain text",
+			text: "Shipped the billing service.",
+			want: []Span{{Text: "Shipped the billing service."}},
+		},
+		{
+			name: "bold and italic",
+			text: "Cut latency by **40%** using *careful* caching",
+			want: []Span{
+				{Text: "Cut latency by "},
+				{Text: "40%", Bold: true},
+				{Text: " using "},
+				{Text: "careful", Italic: true},
+				{Text: " caching"},
+			},
+		},
+		{
+			name: "bold italic",
+			text: "***all in***",
+			want: []Span{{Text: "all in", Bold: true, Italic: true}},
+		},
+		{
+			name: "link with styled text",
+			text: "Published [a **short** paper](https://example.com/paper).",
+			want: []Span{
+				{Text: "Published "},
+				{Text: "a ", URL: "https://example.com/paper"},
+				{Text: "short", Bold: true, URL: "https://example.com/paper"},
+				{Text: " paper", URL: "https://example.com/paper"},
+				{Text: "."},
+			},
+		},
+		{
+			name: "bare host link",
+			text: "[demo](example.com:8080/app)",
+			want: []Span{{Text: "demo", URL: "example.com:8080/app"}},
+		},
+		{
+			name: "brackets without url stay literal",
+			text: "Cited in [1] and [2]",
+			want: []Span{{Text: "Cited in [1] and [2]"}},
+		},
+		{
+			name: "unmatched stars stay literal",
+			text: "Rated 4.9* on App Store",
+			want: []Span{{Text: "Rated 4.9* on App Store"}},
+		},
+		{
+			name: "unmatched star after a closed pair",
+			text: "Used *C* and C* algorithms",
+			want: []Span{
+				{Text: "Used "},
+				{Text: "C", Italic: true},
+				{Text: " and C* algorithms"},
+			},
+		},
+		{
+			name: "unclosed bold stays literal",
+			text: "Cut costs by **40% with *caching*",
+			want: []Span{
+				{Text: "Cut costs by **40% with "},
+				{Text: "caching", Italic: true},
+			},
+		},
+		{
+			name: "stars between spaces stay literal",
+			text: "Cut cost 2 * 3 * 4 times, rated 4 * out of 5 *",
+			want: []Span{{Text: "Cut cost 2 * 3 * 4 times, rated 4 * out of 5 *"}},
+		},
+		{
+			name: "closer after a spaced star",
+			text: "Grew *reach * and* revenue",
+			want: []Span{
+				{Text: "Grew "},
+				{Text: "reach * and", Italic: true},
+				{Text: " revenue"},
+			},
+		},
+		{
+			name: "link url with parentheses",
+			text: "Read [wiki](https://en.wikipedia.org/wiki/Go_(programming_language)).",
+			want: []Span{
+				{Text: "Read "},
+				{Text: "wiki", URL: "https://en.wikipedia.org/wiki/Go_(programming_language)"},
+				{Text: "."},
+			},
+		},
+		{
+			name: "escaped markup",
+			text: `Rated 5\* by \[users\]`,
+			want: []Span{{Text: "Rated 5* by [users]"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tt.text, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parse %q\n got %+v\nwant %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsMalformedMarkup(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		position int
+	}{
+		"empty link text": {text: "See [](https://example.com)", position: 5},
+		"unclosed link":   {text: "See [paper](https://example.com", position: 12},
+		"empty link url":  {text: "See [paper]( )", position: 12},
+		"url with spaces": {text: "See [paper](https://example.com/a b)", position: 12},
+		"javascript link": {text: "See [paper](javascript:alert(1))", position: 12},
+		"data link":       {text: "See [paper](data:text/html,hi)", position: 12},
+		"scheme-relative": {text: "See [x](//evil.com)", position: 8},
+		"bare word":       {text: "See [1,2](note)", position: 10},
+		"absolute path":   {text: "See [x](/admin)", position: 8},
+		"empty http":      {text: "See [x](https://)", position: 8},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tt.text)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected SyntaxError for %q, got %v", tt.text, err)
+			}
+			if syntaxErr.Position != tt.position {
+				t.Fatalf("expected position %d for %q, got %d (%v)", tt.position, tt.text, syntaxErr.Position, err)
+			}
+		})
+	}
+}
+
+func TestPlainStripsMarkup(t *testing.T) {
+	if got := Plain("Cut **40%** via [caching](example.com)"); got != "Cut 40% via caching" {
+		t.Fatalf("unexpected plain text %q", got)
+	}
+	if got := Plain("Cut cost 2 * 3 * 4 times"); got != "Cut cost 2 * 3 * 4 times" {
+		t.Fatalf("expected arithmetic stars kept, got %q", got)
+	}
+	if got := Plain("See [paper](javascript:alert(1))"); got != "See [paper](javascript:alert(1))" {
+		t.Fatalf("expected malformed text unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"resume_maker/backend/internal/keywords"
+	"resume_maker/backend/internal/models"
+	"resume_maker/backend/internal/richtext"
+)
+
+const (
+	maxJobDescriptionLength = 20000
+	// maxJobKeywords caps the keywords reported for one job description.
+	maxJobKeywords = 50
+)
+
+// KeywordService compares job descriptions with resumes. It runs entirely offline.
+type KeywordService struct{}
+
+func NewKeywordService() *KeywordService {
+	return &KeywordService{}
+}
+
+// MatchKeywords extracts the keywords of req.JobDescription and looks for each one in
+// the bullets and technical skills of req.Data. Multi-word skills are recognized as
+// keywords even when the description uses them once.
+func (s *KeywordService) MatchKeywords(_ context.Context, req models.KeywordMatchRequest) (models.KeywordMatchReport, error) {
+	var skills []models.KeywordSource
+	for categoryIndex, category := range req.Data.TechnicalSkills {
+		for skillIndex, skill := range category.Skills {
+			if trimmed := strings.TrimSpace(skill); trimmed != "" {
+				skills = append(skills, models.KeywordSource{
+					Field: fmt.Sprintf("data.technicalSkills[%d].skills[%d]", categoryIndex, skillIndex),
+					Text:  trimmed,
+				})
+			}
+		}
+	}
+	var bullets []models.KeywordSource
+	for _, bullet := range resumeBullets(req.Data) {
+		if text := strings.TrimSpace(richtext.Plain(bullet.text)); text != "" {
+			bullets = append(bullets, models.KeywordSource{Field: bullet.field, Text: text})
+		}
+	}
+
+	if details := validateKeywordMatch(req.JobDescription, len(bullets)+len(skills)); len(details) > 0 {
+		return models.KeywordMatchReport{}, &ValidationError{Details: details}
+	}
+
+	known := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		known = append(known, skill.Text)
+	}
+	bulletDocs, skillDocs := keywordDocuments(bullets), keywordDocuments(skills)
+
+	report := models.KeywordMatchReport{Matched: []models.KeywordMatch{}, Missing: []models.JobKeyword{}}
+	extracted := keywords.Extract(req.JobDescription, known, maxJobKeywords)
+	for _, keyword := range extracted {
+		job := models.JobKeyword{Keyword: keyword.Term, Count: keyword.Count}
+		match := models.KeywordMatch{
+			JobKeyword: job,
+			Bullets:    sourcesContaining(keyword, bullets, bulletDocs),
+			Skills:     sourcesContaining(keyword, skills, skillDocs),
+		}
+		if len(match.Bullets) == 0 && len(match.Skills) == 0 {
+			report.Missing = append(report.Missing, job)
+			continue
+		}
+		report.Matched = append(report.Matched, match)
+	}
+	if len(extracted) > 0 {
+		report.Coverage = math.Round(float64(len(report.Matched))*1000/float64(len(extracted))) / 10
+	}
+	return report, nil
+}
+
+// validateKeywordMatch checks the job description and that the resume has sourceCount
+// bullets and skills to match against.
+func validateKeywordMatch(jobDescription string, sourceCount int) []models.ValidationErrorDetail {
+	var details []models.ValidationErrorDetail
+	switch description := strings.TrimSpace(jobDescription); {
+	case description == "":
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "jobDescription",
+			Message: "must not be empty",
+		})
+	case utf8.RuneCountInString(description) > maxJobDescriptionLength:
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "jobDescription",
+			Message: fmt.Sprintf("must be at most %d characters", maxJobDescriptionLength),
+		})
+	}
+	if sourceCount == 0 {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "data",
+			Message: "must include bullets or technicalSkills to match against",
+		})
+	}
+	return details
+}
+
+func keywordDocuments(sources []models.KeywordSource) []keywords.Document {
+	documents := make([]keywords.Document, len(sources))
+	for index, source := range sources {
+		documents[index] = keywords.NewDocument(source.Text)
+	}
+	return documents
+}
+
+// sourcesContaining returns the sources whose document contains keyword.
+func sourcesContaining(keyword keywords.Keyword, sources []models.KeywordSource, documents []keywords.Document) []models.KeywordSource {
+	found := []models.KeywordSource{}
+	for index, document := range documents {
+		if document.Contains(keyword) {
+			found = append(found, sources[index])
+		}
+	}
+	return found
+}
@@ -5,9 +5,18 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"resume_maker/backend/internal/dates"
 	"resume_maker/backend/internal/models"
+	"resume_maker/backend/internal/richtext"
 )
 
 // ErrPhotoTooLarge indicates the decoded photo payload exceeded the limit.
@@ -15,9 +24,22 @@ var ErrPhotoTooLarge = errors.New("photo is larger than 5MB")
 
 const maxPhotoSizeBytes = 5 * 1024 * 1024
 
+const (
+	maxHeadlineLength   = 120
+	maxSummaryLength    = 600
+	maxSkillLabelLength = 40
+	maxTagLength        = 30
+	maxFitToPages       = 10
+	maxGapWarning       = 120
+	minMarginMM         = 5.0
+	maxMarginMM         = 50.0
+)
+
 // PDFGenerator abstracts the rendering module to keep service code testable.
 type PDFGenerator interface {
-	Generate(req models.GeneratePDFRequest) ([]byte, error)
+	Render(req models.GeneratePDFRequest) (models.GeneratedPDF, error)
+	Layout(req models.GeneratePDFRequest) (models.LayoutReport, error)
+	HasTemplate(id string) bool
 }
 
 // ValidationError returns field-level validation failures.
@@ -32,30 +54,98 @@ func (e *ValidationError) Error() string {
 // PDFService handles validation and delegates rendering to the generator.
 type PDFService struct {
 	generator PDFGenerator
+	// now is the clock that timeline warnings treat as the present.
+	now func() time.Time
 }
 
 func NewPDFService(generator PDFGenerator) *PDFService {
-	return &PDFService{generator: generator}
+	return &PDFService{generator: generator, now: time.Now}
 }
 
-func (s *PDFService) GeneratePDF(_ context.Context, req models.GeneratePDFRequest) ([]byte, error) {
-	details := validate(req)
+func (s *PDFService) GeneratePDF(_ context.Context, req models.GeneratePDFRequest) (models.GeneratedPDF, error) {
+	if err := s.validateRequest(req); err != nil {
+		return models.GeneratedPDF{}, err
+	}
+
+	result, err := s.generator.Render(req)
+	if err != nil {
+		return models.GeneratedPDF{}, fmt.Errorf("generate pdf via renderer: %w", err)
+	}
+	result.Warnings = append(requestWarnings(req, s.now()), result.Warnings...)
+
+	return result, nil
+}
+
+// LayoutResume validates req and reports how it lays out without producing PDF bytes.
+func (s *PDFService) LayoutResume(_ context.Context, req models.GeneratePDFRequest) (models.LayoutReport, error) {
+	if err := s.validateRequest(req); err != nil {
+		return models.LayoutReport{}, err
+	}
+
+	report, err := s.generator.Layout(req)
+	if err != nil {
+		return models.LayoutReport{}, fmt.Errorf("lay out resume via renderer: %w", err)
+	}
+	report.Warnings = append(requestWarnings(req, s.now()), report.Warnings...)
+
+	return report, nil
+}
+
+// ValidateResume reports every validation error and warning for req without producing
+// PDF bytes. A request with errors gets no warnings; otherwise the resume is laid out so
+// that text overflowing the page is reported too. Only layout failures are returned as
+// errors.
+func (s *PDFService) ValidateResume(_ context.Context, req models.GeneratePDFRequest) (models.ValidationReport, error) {
+	details := s.requestDetails(req)
+	if len(details) == 0 && strings.TrimSpace(req.Photo) != "" {
+		var validationErr *ValidationError
+		switch err := validatePhoto(req.Photo); {
+		case errors.As(err, &validationErr):
+			details = validationErr.Details
+		case errors.Is(err, ErrPhotoTooLarge):
+			details = []models.ValidationErrorDetail{{Field: "photo", Message: "must be at most 5MB decoded"}}
+		}
+	}
 	if len(details) > 0 {
-		return nil, &ValidationError{Details: details}
+		return models.ValidationReport{Errors: details, Warnings: []models.ValidationWarning{}}, nil
+	}
+
+	layout, err := s.generator.Layout(req)
+	if err != nil {
+		return models.ValidationReport{}, fmt.Errorf("lay out resume via renderer: %w", err)
+	}
+
+	warnings := append(requestWarnings(req, s.now()), layout.Warnings...)
+	if warnings == nil {
+		warnings = []models.ValidationWarning{}
+	}
+	return models.ValidationReport{Valid: true, Errors: []models.ValidationErrorDetail{}, Warnings: warnings}, nil
+}
+
+func (s *PDFService) validateRequest(req models.GeneratePDFRequest) error {
+	if details := s.requestDetails(req); len(details) > 0 {
+		return &ValidationError{Details: details}
 	}
 
 	if strings.TrimSpace(req.Photo) != "" {
 		if err := validatePhoto(req.Photo); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	bytes, err := s.generator.Generate(req)
-	if err != nil {
-		return nil, fmt.Errorf("generate pdf via renderer: %w", err)
-	}
+	return nil
+}
 
-	return bytes, nil
+// requestDetails returns the field errors of req, including an unknown template.
+func (s *PDFService) requestDetails(req models.GeneratePDFRequest) []models.ValidationErrorDetail {
+	details := validate(req)
+	if !s.generator.HasTemplate(req.TemplateID) {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "templateId",
+			Message: "must name an available template",
+		})
+	}
+	return details
 }
 
 func validate(req models.GeneratePDFRequest) []models.ValidationErrorDetail {
@@ -75,6 +165,20 @@ func validate(req models.GeneratePDFRequest) []models.ValidationErrorDetail {
 		})
 	}
 
+	if utf8.RuneCountInString(strings.TrimSpace(req.Data.PersonalInfo.Headline)) > maxHeadlineLength {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "data.personalInfo.headline",
+			Message: fmt.Sprintf("must be at most %d characters", maxHeadlineLength),
+		})
+	}
+
+	if utf8.RuneCountInString(strings.TrimSpace(req.Data.PersonalInfo.Summary)) > maxSummaryLength {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "data.personalInfo.summary",
+			Message: fmt.Sprintf("must be at most %d characters", maxSummaryLength),
+		})
+	}
+
 	if req.Settings.ShowPhoto && strings.TrimSpace(req.Photo) == "" {
 		details = append(details, models.ValidationErrorDetail{
 			Field:   "photo",
@@ -82,25 +186,38 @@ func validate(req models.GeneratePDFRequest) []models.ValidationErrorDetail {
 		})
 	}
 
-	hasTechnicalSkills := strings.TrimSpace(req.Data.TechnicalSkills.Languages) != "" ||
-		strings.TrimSpace(req.Data.TechnicalSkills.Frameworks) != "" ||
-		strings.TrimSpace(req.Data.TechnicalSkills.DeveloperTools) != "" ||
-		strings.TrimSpace(req.Data.TechnicalSkills.Libraries) != ""
+	hasTechnicalSkills := req.Data.TechnicalSkills.HasSkills()
+
+	hasCustomSections := false
+	for _, custom := range req.Data.CustomSections {
+		if len(custom.Entries) > 0 {
+			hasCustomSections = true
+		}
+	}
 
-	if len(req.Data.Experience) == 0 && len(req.Data.Education) == 0 && len(req.Data.Projects) == 0 && !hasTechnicalSkills {
+	if len(req.Data.Experience) == 0 && len(req.Data.Education) == 0 && len(req.Data.Projects) == 0 && !hasTechnicalSkills && !hasCustomSections {
 		details = append(details, models.ValidationErrorDetail{
 			Field:   "data",
-			Message: "at least one content section must be filled (experience, education, projects, or technicalSkills)",
+			Message: "at least one content section must be filled (experience, education, projects, technicalSkills, or customSections)",
 		})
 	}
 
 	for index, exp := range req.Data.Experience {
+		details = append(details, validateWebURL(fmt.Sprintf("data.experience[%d].url", index), exp.URL)...)
+		details = append(details, validateTags(fmt.Sprintf("data.experience[%d].tags", index), exp.Tags)...)
+		if len(exp.Roles) > 0 {
+			details = append(details, validateGroupedExperience(fmt.Sprintf("data.experience[%d]", index), exp)...)
+			continue
+		}
 		if strings.TrimSpace(exp.Role) == "" && strings.TrimSpace(exp.Company) == "" {
 			details = append(details, models.ValidationErrorDetail{
 				Field:   fmt.Sprintf("data.experience[%d]", index),
 				Message: "must include role or company",
 			})
 		}
+		details = append(details, validateDateRange(fmt.Sprintf("data.experience[%d]", index), exp.StartDate, exp.EndDate)...)
+		details = append(details, validateEmploymentType(fmt.Sprintf("data.experience[%d].employmentType", index), exp.EmploymentType)...)
+		details = append(details, validateBullets(fmt.Sprintf("data.experience[%d].bullets", index), exp.Bullets, 1)...)
 	}
 
 	for index, edu := range req.Data.Education {
@@ -110,6 +227,9 @@ func validate(req models.GeneratePDFRequest) []models.ValidationErrorDetail {
 				Message: "must not be empty",
 			})
 		}
+		details = append(details, validateDateRange(fmt.Sprintf("data.education[%d]", index), edu.StartDate, edu.EndDate)...)
+		details = append(details, validateGPA(fmt.Sprintf("data.education[%d]", index), edu.GPA, edu.GPAScale)...)
+		details = append(details, validateBullets(fmt.Sprintf("data.education[%d].bullets", index), edu.Bullets, 1)...)
 	}
 
 	for index, project := range req.Data.Projects {
@@ -119,6 +239,172 @@ func validate(req models.GeneratePDFRequest) []models.ValidationErrorDetail {
 				Message: "must not be empty",
 			})
 		}
+		details = append(details, validateWebURL(fmt.Sprintf("data.projects[%d].url", index), project.URL)...)
+		details = append(details, validateWebURL(fmt.Sprintf("data.projects[%d].repoUrl", index), project.RepoURL)...)
+		details = append(details, validateTags(fmt.Sprintf("data.projects[%d].tags", index), project.Tags)...)
+		details = append(details, validateDateRange(fmt.Sprintf("data.projects[%d]", index), project.StartDate, project.EndDate)...)
+		details = append(details, validateBullets(fmt.Sprintf("data.projects[%d].bullets", index), project.Bullets, 1)...)
+	}
+
+	for index, category := range req.Data.TechnicalSkills {
+		label := strings.TrimSpace(category.Label)
+		switch {
+		case label == "":
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("data.technicalSkills[%d].label", index),
+				Message: "must not be empty",
+			})
+		case utf8.RuneCountInString(label) > maxSkillLabelLength:
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("data.technicalSkills[%d].label", index),
+				Message: fmt.Sprintf("must be at most %d characters", maxSkillLabelLength),
+			})
+		}
+		if len(category.Items()) == 0 {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("data.technicalSkills[%d].skills", index),
+				Message: "must list at least one skill",
+			})
+		}
+	}
+
+	for sectionIndex, custom := range req.Data.CustomSections {
+		if strings.TrimSpace(custom.Title) == "" {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("data.customSections[%d].title", sectionIndex),
+				Message: "must not be empty",
+			})
+		}
+		if len(custom.Entries) == 0 {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("data.customSections[%d].entries", sectionIndex),
+				Message: "must include at least one entry",
+			})
+		}
+		for entryIndex, entry := range custom.Entries {
+			if strings.TrimSpace(entry.Primary) == "" && strings.TrimSpace(entry.Secondary) == "" {
+				details = append(details, models.ValidationErrorDetail{
+					Field:   fmt.Sprintf("data.customSections[%d].entries[%d]", sectionIndex, entryIndex),
+					Message: "must include primary or secondary text",
+				})
+			}
+			details = append(details, validateBullets(fmt.Sprintf("data.customSections[%d].entries[%d].bullets", sectionIndex, entryIndex), entry.Bullets, 1)...)
+		}
+	}
+
+	if req.Variant != nil {
+		if len(req.Variant.IncludeTags) == 0 {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   "variant.includeTags",
+				Message: "must list at least one tag",
+			})
+		}
+		details = append(details, validateTags("variant.includeTags", req.Variant.IncludeTags)...)
+	}
+
+	seenSections := make(map[string]bool, len(req.Settings.SectionOrder))
+	for index, section := range req.Settings.SectionOrder {
+		key := strings.TrimSpace(section)
+		switch {
+		case !models.IsSectionKey(key):
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("settings.sectionOrder[%d]", index),
+				Message: "must be one of: " + strings.Join(models.SectionKeys, ", "),
+			})
+		case seenSections[key]:
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("settings.sectionOrder[%d]", index),
+				Message: "must not repeat a section",
+			})
+		}
+		seenSections[key] = true
+	}
+
+	for _, section := range sortedKeys(req.Settings.SectionVisibility) {
+		if !models.IsSectionKey(section) {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   "settings.sectionVisibility." + section,
+				Message: "must be one of: " + strings.Join(models.SectionKeys, ", "),
+			})
+		}
+	}
+
+	filledSections := map[string]bool{
+		models.SectionEducation:       len(req.Data.Education) > 0,
+		models.SectionExperience:      len(req.Data.Experience) > 0,
+		models.SectionProjects:        len(req.Data.Projects) > 0,
+		models.SectionTechnicalSkills: hasTechnicalSkills,
+		models.SectionCustom:          hasCustomSections,
+	}
+	hasFilledSection := false
+	hasVisibleSection := false
+	for section, filled := range filledSections {
+		if !filled {
+			continue
+		}
+		hasFilledSection = true
+		if visible, ok := req.Settings.SectionVisibility[section]; !ok || visible {
+			hasVisibleSection = true
+		}
+	}
+	if hasFilledSection && !hasVisibleSection {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.sectionVisibility",
+			Message: "must leave at least one filled section visible",
+		})
+	}
+
+	if req.Settings.FitToPages < 0 || req.Settings.FitToPages > maxFitToPages {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.fitToPages",
+			Message: fmt.Sprintf("must be between 0 and %d", maxFitToPages),
+		})
+	}
+
+	if strings.TrimSpace(req.Settings.PageSize) != "" {
+		if _, ok := models.NormalizePageSize(req.Settings.PageSize); !ok {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   "settings.pageSize",
+				Message: "must be one of: A4, Letter, Legal",
+			})
+		}
+	}
+	details = append(details, validateMargins(req.Settings.Margins)...)
+	details = append(details, validateTheme(req.Settings.Theme)...)
+
+	if dateFormat := strings.TrimSpace(req.Settings.DateFormat); dateFormat != "" && !containsFold(models.DateFormats, dateFormat) {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.dateFormat",
+			Message: "must be one of: " + strings.Join(models.DateFormats, ", "),
+		})
+	}
+
+	if sortEntries := strings.TrimSpace(req.Settings.SortEntries); sortEntries != "" && !containsFold(models.SortOrders, sortEntries) {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.sortEntries",
+			Message: "must be one of: " + strings.Join(models.SortOrders, ", "),
+		})
+	}
+
+	if req.Settings.GapWarningMonths < 0 || req.Settings.GapWarningMonths > maxGapWarning {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.gapWarningMonths",
+			Message: fmt.Sprintf("must be between 0 and %d", maxGapWarning),
+		})
+	}
+
+	if bulletStyle := strings.TrimSpace(req.Settings.BulletStyle); bulletStyle != "" && !containsFold(models.BulletStyles, bulletStyle) {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.bulletStyle",
+			Message: "must be one of: " + strings.Join(models.BulletStyles, ", "),
+		})
+	}
+
+	if headerLayout := strings.TrimSpace(req.Settings.HeaderLayout); headerLayout != "" && !containsFold(models.HeaderLayouts, headerLayout) {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.headerLayout",
+			Message: "must be one of: " + strings.Join(models.HeaderLayouts, ", "),
+		})
 	}
 
 	fontFamily := strings.ToLower(strings.TrimSpace(req.Settings.FontFamily))
@@ -144,6 +430,284 @@ func validate(req models.GeneratePDFRequest) []models.ValidationErrorDetail {
 	return details
 }
 
+// validateMargins checks a margin preset name or that every explicit margin is within
+// range. Sides left out of the object keep the template margins and are not checked.
+func validateMargins(margins *models.MarginSetting) []models.ValidationErrorDetail {
+	if margins == nil {
+		return nil
+	}
+	if margins.Preset != "" {
+		switch strings.ToLower(strings.TrimSpace(margins.Preset)) {
+		case models.MarginsNarrow, models.MarginsNormal, models.MarginsWide:
+			return nil
+		}
+		return []models.ValidationErrorDetail{{
+			Field:   "settings.margins",
+			Message: "must be one of: narrow, normal, wide, or an object of millimetre values",
+		}}
+	}
+
+	var details []models.ValidationErrorDetail
+	sides := []struct {
+		name  string
+		value *float64
+	}{
+		{"top", margins.Top},
+		{"right", margins.Right},
+		{"bottom", margins.Bottom},
+		{"left", margins.Left},
+	}
+	for _, side := range sides {
+		if side.value != nil && (*side.value < minMarginMM || *side.value > maxMarginMM) {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   "settings.margins." + side.name,
+				Message: fmt.Sprintf("must be between %g and %g mm", minMarginMM, maxMarginMM),
+			})
+		}
+	}
+	return details
+}
+
+// validateTheme checks the theme preset name and that every color override is #rrggbb.
+func validateTheme(theme *models.ThemeSetting) []models.ValidationErrorDetail {
+	if theme == nil {
+		return nil
+	}
+
+	var details []models.ValidationErrorDetail
+	if strings.TrimSpace(theme.Preset) != "" && !models.IsThemePreset(theme.Preset) {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   "settings.theme.preset",
+			Message: "must be one of: " + strings.Join(models.ThemePresets, ", "),
+		})
+	}
+	colors := []struct {
+		name  string
+		value string
+	}{
+		{"accent", theme.Accent},
+		{"text", theme.Text},
+		{"link", theme.Link},
+		{"rule", theme.Rule},
+	}
+	for _, color := range colors {
+		if strings.TrimSpace(color.value) == "" {
+			continue
+		}
+		if _, _, _, ok := models.ParseHexColor(color.value); !ok {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   "settings.theme." + color.name,
+				Message: "must be a hex color such as #1f3a5f",
+			})
+		}
+	}
+	return details
+}
+
+// validateGroupedExperience checks an experience entry that lists several roles: the
+// company heads the group, each role needs a title, and role-level fields must not also
+// be set on the entry itself.
+func validateGroupedExperience(field string, exp models.ExperienceEntry) []models.ValidationErrorDetail {
+	var details []models.ValidationErrorDetail
+	if strings.TrimSpace(exp.Company) == "" {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   field + ".company",
+			Message: "must not be empty when roles are listed",
+		})
+	}
+	entryFields := []struct {
+		name string
+		set  bool
+	}{
+		{"role", strings.TrimSpace(exp.Role) != ""},
+		{"startDate", strings.TrimSpace(exp.StartDate) != ""},
+		{"endDate", strings.TrimSpace(exp.EndDate) != ""},
+		{"employmentType", strings.TrimSpace(exp.EmploymentType) != ""},
+		{"bullets", len(exp.Bullets) > 0},
+	}
+	for _, entryField := range entryFields {
+		if entryField.set {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   field + "." + entryField.name,
+				Message: "must be empty when roles are listed; set it on each role instead",
+			})
+		}
+	}
+
+	for index, role := range exp.Roles {
+		roleField := fmt.Sprintf("%s.roles[%d]", field, index)
+		if strings.TrimSpace(role.Role) == "" {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   roleField + ".role",
+				Message: "must not be empty",
+			})
+		}
+		details = append(details, validateDateRange(roleField, role.StartDate, role.EndDate)...)
+		details = append(details, validateEmploymentType(roleField+".employmentType", role.EmploymentType)...)
+		details = append(details, validateTags(roleField+".tags", role.Tags)...)
+		details = append(details, validateBullets(roleField+".bullets", role.Bullets, 1)...)
+	}
+	return details
+}
+
+// validateDateRange checks that the endDate of the entry at field does not precede its
+// startDate. Dates that do not parse, and a start of Present, are free-form text: they
+// are shown as entered and reported by timelineWarnings instead.
+func validateDateRange(field string, startDate string, endDate string) []models.ValidationErrorDetail {
+	start, err := dates.Parse(startDate)
+	if err != nil || start.Present {
+		return nil
+	}
+	end, err := dates.Parse(endDate)
+	if err != nil || !end.Before(start) {
+		return nil
+	}
+	return []models.ValidationErrorDetail{{Field: field + ".endDate", Message: "must not be before startDate"}}
+}
+
+// validateGPA checks the gpa and gpaScale of the education entry at field: each must be
+// a positive number when set, and the GPA must not exceed its scale.
+func validateGPA(field string, gpa string, scale string) []models.ValidationErrorDetail {
+	var details []models.ValidationErrorDetail
+	gpaValue, gpaOK := parseGPANumber(gpa)
+	if strings.TrimSpace(gpa) != "" && !gpaOK {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   field + ".gpa",
+			Message: "must be a positive number such as 3.8",
+		})
+	}
+	scaleValue, scaleOK := parseGPANumber(scale)
+	if strings.TrimSpace(scale) != "" && !scaleOK {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   field + ".gpaScale",
+			Message: "must be a positive number such as 4.0",
+		})
+	}
+	if gpaOK && scaleOK && gpaValue > scaleValue {
+		details = append(details, models.ValidationErrorDetail{
+			Field:   field + ".gpa",
+			Message: fmt.Sprintf("must not exceed gpaScale %s", strings.TrimSpace(scale)),
+		})
+	}
+	return details
+}
+
+func parseGPANumber(value string) (float64, bool) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) || number <= 0 {
+		return 0, false
+	}
+	return number, true
+}
+
+// validateWebURL checks that a link, when set, is a web address: an http or https URL,
+// or a bare host and path that the renderer opens over https.
+func validateWebURL(field string, value string) []models.ValidationErrorDetail {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return nil
+	}
+	if strings.IndexFunc(trimmed, unicode.IsSpace) >= 0 {
+		return []models.ValidationErrorDetail{{Field: field, Message: "must not contain spaces"}}
+	}
+	if !strings.Contains(trimmed, "://") {
+		trimmed = "https://" + trimmed
+	}
+	parsed, err := url.Parse(trimmed)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.User != nil || !strings.Contains(parsed.Hostname(), ".") {
+		return []models.ValidationErrorDetail{{
+			Field:   field,
+			Message: "must be an http or https address such as https://github.com/ada/engine",
+		}}
+	}
+	return nil
+}
+
+// validateTags checks that every tag is non-blank and at most maxTagLength characters.
+func validateTags(field string, tags []string) []models.ValidationErrorDetail {
+	var details []models.ValidationErrorDetail
+	for index, tag := range tags {
+		trimmed := strings.TrimSpace(tag)
+		switch {
+		case trimmed == "":
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("%s[%d]", field, index),
+				Message: "must not be empty",
+			})
+		case utf8.RuneCountInString(trimmed) > maxTagLength:
+			details = append(details, models.ValidationErrorDetail{
+				Field:   fmt.Sprintf("%s[%d]", field, index),
+				Message: fmt.Sprintf("must be at most %d characters", maxTagLength),
+			})
+		}
+	}
+	return details
+}
+
+// validateEmploymentType checks that an experience employment type, when set, is known.
+func validateEmploymentType(field string, employmentType string) []models.ValidationErrorDetail {
+	if value := strings.TrimSpace(employmentType); value == "" || containsFold(models.EmploymentTypes, value) {
+		return nil
+	}
+	return []models.ValidationErrorDetail{{
+		Field:   field,
+		Message: "must be one of: " + strings.Join(models.EmploymentTypes, ", "),
+	}}
+}
+
+// validateBullets reports bullets whose inline markup does not parse, bullets with
+// sub-bullets but no text, and sub-bullets nested deeper than models.MaxBulletDepth.
+// level is the nesting level of bullets, starting at 1.
+func validateBullets(field string, bullets []models.Bullet, level int) []models.ValidationErrorDetail {
+	var details []models.ValidationErrorDetail
+	for index, bullet := range bullets {
+		bulletField := fmt.Sprintf("%s[%d]", field, index)
+		if _, err := richtext.Parse(bullet.Text); err != nil {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   bulletField,
+				Message: "has malformed markup: " + err.Error(),
+			})
+		}
+		details = append(details, validateTags(bulletField+".tags", bullet.Tags)...)
+		if len(bullet.Children) == 0 {
+			continue
+		}
+		if strings.TrimSpace(bullet.Text) == "" {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   bulletField + ".text",
+				Message: "must not be empty when the bullet has children",
+			})
+		}
+		if level >= models.MaxBulletDepth {
+			details = append(details, models.ValidationErrorDetail{
+				Field:   bulletField + ".children",
+				Message: fmt.Sprintf("must not nest bullets more than %d levels deep", models.MaxBulletDepth),
+			})
+			continue
+		}
+		details = append(details, validateBullets(bulletField+".children", bullet.Children, level+1)...)
+	}
+	return details
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(values map[string]bool) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func validatePhoto(photo string) error {
 	value := strings.TrimSpace(photo)
 	if value == "" {
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"resume_maker/backend/internal/dates"
+	"resume_maker/backend/internal/models"
+)
+
+// timelinePeriod is one dated experience role or education entry. Months are counted
+// as year*12 + month - 1; a year-only date spans its whole year, so first and last are
+// the widest reading of the range and latestStart and earliestEnd the narrowest.
+type timelinePeriod struct {
+	field       string
+	group       int
+	fullTime    bool
+	first, last int
+	latestStart int
+	earliestEnd int
+}
+
+// timelineWarnings reports dates it cannot read, future start dates, overlapping
+// full-time roles, and gaps between roles and studies longer than
+// settings.gapWarningMonths. It expects a request that passed validate, and never fails:
+// unreadable dates are reported and otherwise skipped.
+func timelineWarnings(req models.GeneratePDFRequest, now time.Time) []models.ValidationWarning {
+	current, _ := dates.Date{Present: true}.Span(now)
+
+	var warnings []models.ValidationWarning
+	var periods []timelinePeriod
+	addPeriod := func(field string, group int, fullTime bool, startDate string, endDate string) {
+		warnings = append(warnings, unparsedDateWarnings(field, startDate, endDate)...)
+		period, ok := newTimelinePeriod(field, startDate, endDate, now)
+		if !ok {
+			return
+		}
+		if period.first > current {
+			warnings = append(warnings, models.ValidationWarning{
+				Field:   field + ".startDate",
+				Code:    models.WarningFutureStartDate,
+				Message: "is after the current month",
+			})
+		}
+		// Projects are only checked for future start dates.
+		if group < 0 {
+			return
+		}
+		period.group = group
+		period.fullTime = fullTime
+		periods = append(periods, period)
+	}
+
+	for index, exp := range req.Data.Experience {
+		field := fmt.Sprintf("data.experience[%d]", index)
+		if len(exp.Roles) == 0 {
+			addPeriod(field, index, isFullTime(exp.EmploymentType), exp.StartDate, exp.EndDate)
+			continue
+		}
+		for roleIndex, role := range exp.Roles {
+			addPeriod(fmt.Sprintf("%s.roles[%d]", field, roleIndex), index, isFullTime(role.EmploymentType), role.StartDate, role.EndDate)
+		}
+	}
+	for index, edu := range req.Data.Education {
+		// Studies close gaps between roles but are never reported as overlapping one.
+		addPeriod(fmt.Sprintf("data.education[%d]", index), len(req.Data.Experience)+index, false, edu.StartDate, edu.EndDate)
+	}
+	for index, project := range req.Data.Projects {
+		addPeriod(fmt.Sprintf("data.projects[%d]", index), -1, false, project.StartDate, project.EndDate)
+	}
+
+	gapMonths := req.Settings.GapWarningMonths
+	if gapMonths <= 0 {
+		gapMonths = models.DefaultGapWarningMonths
+	}
+	warnings = append(warnings, overlapWarnings(periods)...)
+	return append(warnings, gapWarnings(periods, gapMonths)...)
+}
+
+// unparsedDateWarnings reports the set dates of the entry at field that are free-form
+// text, including a start date of Present.
+func unparsedDateWarnings(field string, startDate string, endDate string) []models.ValidationWarning {
+	const accepted = "; it is shown as entered and skipped when sorting and checking the timeline"
+
+	var warnings []models.ValidationWarning
+	if strings.TrimSpace(startDate) != "" {
+		switch start, err := dates.Parse(startDate); {
+		case err != nil:
+			warnings = append(warnings, models.ValidationWarning{
+				Field:   field + ".startDate",
+				Code:    models.WarningUnparsedDate,
+				Message: "is not a date such as Jan 2022, 2022-01, 01/2022 or 2022" + accepted,
+			})
+		case start.Present:
+			warnings = append(warnings, models.ValidationWarning{
+				Field:   field + ".startDate",
+				Code:    models.WarningUnparsedDate,
+				Message: "is Present, which cannot start a range" + accepted,
+			})
+		}
+	}
+	if strings.TrimSpace(endDate) != "" {
+		if _, err := dates.Parse(endDate); err != nil {
+			warnings = append(warnings, models.ValidationWarning{
+				Field:   field + ".endDate",
+				Code:    models.WarningUnparsedDate,
+				Message: "is not a date such as Jan 2022, 2022-01, 01/2022, 2022 or Present" + accepted,
+			})
+		}
+	}
+	return warnings
+}
+
+// newTimelinePeriod reads a date range. A range without a readable start is skipped,
+// and one without a readable end is taken to end when it started.
+func newTimelinePeriod(field string, startDate string, endDate string, now time.Time) (timelinePeriod, bool) {
+	start, err := dates.Parse(startDate)
+	if err != nil || start.Present {
+		return timelinePeriod{}, false
+	}
+	end, err := dates.Parse(endDate)
+	if err != nil {
+		end = start
+	}
+
+	period := timelinePeriod{field: field}
+	period.first, period.latestStart = start.Span(now)
+	period.earliestEnd, period.last = end.Span(now)
+	return period, true
+}
+
+// overlapWarnings reports pairs of full-time roles at different companies whose ranges
+// certainly overlap. A role that starts in the month the other ends does not overlap it.
+func overlapWarnings(periods []timelinePeriod) []models.ValidationWarning {
+	var warnings []models.ValidationWarning
+	for i, a := range periods {
+		if !a.fullTime {
+			continue
+		}
+		for _, b := range periods[i+1:] {
+			if !b.fullTime || a.group == b.group {
+				continue
+			}
+			months := min(a.earliestEnd, b.earliestEnd) - max(a.latestStart, b.latestStart)
+			if months <= 0 {
+				continue
+			}
+			later, earlier := b, a
+			if a.latestStart > b.latestStart {
+				later, earlier = a, b
+			}
+			warnings = append(warnings, models.ValidationWarning{
+				Field:   later.field,
+				Code:    models.WarningOverlappingRoles,
+				Message: fmt.Sprintf("overlaps the full-time role %s by %s", earlier.field, pluralMonths(months)),
+			})
+		}
+	}
+	return warnings
+}
+
+// gapWarnings reports periods that start more than gapMonths after every earlier period
+// has ended. Ranges are read at their widest so that imprecise dates do not open gaps.
+func gapWarnings(periods []timelinePeriod, gapMonths int) []models.ValidationWarning {
+	if len(periods) < 2 {
+		return nil
+	}
+	ordered := append([]timelinePeriod(nil), periods...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].first < ordered[j].first
+	})
+
+	var warnings []models.ValidationWarning
+	coveredUntil := ordered[0].last
+	for _, period := range ordered[1:] {
+		if gap := period.first - coveredUntil - 1; gap > gapMonths {
+			warnings = append(warnings, models.ValidationWarning{
+				Field:   period.field,
+				Code:    models.WarningTimelineGap,
+				Message: fmt.Sprintf("starts %s after the previous role or studies ended", pluralMonths(gap)),
+			})
+		}
+		coveredUntil = max(coveredUntil, period.last)
+	}
+	return warnings
+}
+
+func isFullTime(employmentType string) bool {
+	value := strings.TrimSpace(employmentType)
+	return value == "" || strings.EqualFold(value, models.EmploymentFullTime)
+}
+
+func pluralMonths(months int) string {
+	if months == 1 {
+		return "1 month"
+	}
+	return fmt.Sprintf("%d months", months)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"resume_maker/backend/internal/models"
+)
+
+var testNow = time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+func TestTimelineWarningsReportsGapsOverlapsAndFutureStarts(t *testing.T) {
+	req := validRequest()
+	req.Data.Experience = []models.ExperienceEntry{
+		{Role: "Analyst", Company: "Babbage & Co", StartDate: "Jan 2015", EndDate: "Dec 2017"},
+		{Role: "Engineer", Company: "Difference Ltd", StartDate: "Jan 2020", EndDate: "Present"},
+		{Role: "Consultant", Company: "Engine Works", StartDate: "Jun 2021", EndDate: "Mar 2022"},
+		{Role: "Tutor", Company: "Evening School", StartDate: "Jan 2021", EndDate: "Present", EmploymentType: models.EmploymentPartTime},
+	}
+	req.Data.Education = []models.EducationEntry{
+		{Institution: "University of London", Degree: "BSc", StartDate: "2011", EndDate: "2014"},
+		{Institution: "Open University", Degree: "MSc", StartDate: "Sep 2099", EndDate: "Jun 2100"},
+	}
+	req.Settings.GapWarningMonths = 12
+
+	// The 2014-2015 gap is within 12 months, and the part-time tutoring role overlaps
+	// without a warning.
+	want := []string{
+		"futureStartDate data.education[1].startDate",
+		"overlappingRoles data.experience[2]",
+		"timelineGap data.experience[1]",
+		"timelineGap data.education[1]",
+	}
+	if got := warningKeys(timelineWarnings(req, testNow)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v, got %v", want, got)
+	}
+}
+
+func TestTimelineWarningsReportsUnparsedDates(t *testing.T) {
+	req := validRequest()
+	req.Data.Experience = []models.ExperienceEntry{
+		{Role: "Research Intern", StartDate: "Summer 2021", EndDate: "Fall 2021"},
+		{Role: "Volunteer", StartDate: "Present"},
+	}
+	req.Data.Education = []models.EducationEntry{
+		{Institution: "University of London", StartDate: "Sep 2021", EndDate: "Expected May 2025"},
+	}
+
+	want := []string{
+		"unparsedDate data.experience[0].startDate",
+		"unparsedDate data.experience[0].endDate",
+		"unparsedDate data.experience[1].startDate",
+		"unparsedDate data.education[0].endDate",
+	}
+	if got := warningKeys(timelineWarnings(req, testNow)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v, got %v", want, got)
+	}
+}
+
+func TestValidateRejectsOnlyReversedDateRanges(t *testing.T) {
+	req := validRequest()
+	req.Data.Experience = []models.ExperienceEntry{
+		{Role: "Analyst", StartDate: "Summer 2022", EndDate: "Present"},
+		{Role: "Engineer", StartDate: "2022-06", EndDate: "Jan 2022"},
+		{Company: "Engines Ltd", Roles: []models.ExperienceRole{{Role: "Lead", StartDate: "present", EndDate: "13/2023"}}},
+	}
+	req.Data.Education = []models.EducationEntry{
+		{Institution: "University of London", StartDate: "2019", EndDate: "2018"},
+	}
+	req.Data.Projects = []models.ProjectEntry{
+		{Name: "Notes", StartDate: "01/2020", EndDate: "2020"},
+	}
+
+	// Free-form dates, a start of Present and a year-only end in the start year pass.
+	want := []string{"data.experience[1].endDate", "data.education[0].endDate"}
+	if got := detailFields(validate(req)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected errors on %v, got %v", want, got)
+	}
+}
+
+// validRequest returns a request that passes validate, for tests to modify.
+func validRequest() models.GeneratePDFRequest {
+	return models.GeneratePDFRequest{
+		Data: models.ResumeData{
+			PersonalInfo: models.PersonalInfo{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"},
+			Projects:     []models.ProjectEntry{{Name: "Analytical Engine Notes"}},
+		},
+		Settings: models.ResumeSetting{FontSize: "medium", FontFamily: "times"},
+	}
+}
+
+// warningKeys lists warnings as "code field", in order.
+func warningKeys(warnings []models.ValidationWarning) []string {
+	keys := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		keys = append(keys, warning.Code+" "+warning.Field)
+	}
+	return keys
+}
+
+// detailFields lists the fields of validation errors, in order.
+func detailFields(details []models.ValidationErrorDetail) []string {
+	fields := make([]string, 0, len(details))
+	for _, detail := range details {
+		fields = append(fields, detail.Field)
+	}
+	return fields
+}
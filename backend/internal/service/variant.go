@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"resume_maker/backend/internal/models"
+	"resume_maker/backend/internal/variant"
+)
+
+// variantWarnings reports include tags that no entry, role or bullet carries, so the
+// variant renders only the untagged content for them.
+func variantWarnings(req models.GeneratePDFRequest) []models.ValidationWarning {
+	if req.Variant == nil {
+		return nil
+	}
+	used := requestTags(req)
+
+	var warnings []models.ValidationWarning
+	for index, tag := range req.Variant.IncludeTags {
+		if used[variant.NormalizeTag(tag)] {
+			continue
+		}
+		warnings = append(warnings, models.ValidationWarning{
+			Field:   fmt.Sprintf("variant.includeTags[%d]", index),
+			Code:    models.WarningUnmatchedTag,
+			Message: fmt.Sprintf("tag %q is not set on any entry or bullet", strings.TrimSpace(tag)),
+		})
+	}
+	return warnings
+}
+
+// maskBullets returns bullets with every bullet filter excludes, and so its
+// sub-bullets, blanked rather than removed.
+func maskBullets(filter variant.Filter, bullets []models.Bullet) []models.Bullet {
+	if bullets == nil {
+		return nil
+	}
+	kept := make([]models.Bullet, len(bullets))
+	for index, bullet := range bullets {
+		if filter.Includes(bullet.Tags) {
+			bullet.Children = maskBullets(filter, bullet.Children)
+			kept[index] = bullet
+		}
+	}
+	return kept
+}
+
+// applyVariant returns req as its variant renders it. Excluded experience entries,
+// roles, projects and bullets are blanked rather than removed, so warnings computed on
+// the result keep the field paths of req; a blank item has nothing to warn about.
+func applyVariant(req models.GeneratePDFRequest) models.GeneratePDFRequest {
+	filter := variant.NewFilter(req.Variant)
+	if !filter.Active() {
+		return req
+	}
+	data := req.Data
+
+	data.Experience = make([]models.ExperienceEntry, len(req.Data.Experience))
+	for index, exp := range req.Data.Experience {
+		if !filter.Includes(exp.Tags) {
+			continue
+		}
+		exp.Bullets = maskBullets(filter, exp.Bullets)
+		if len(exp.Roles) > 0 {
+			roles := make([]models.ExperienceRole, len(exp.Roles))
+			kept := false
+			for roleIndex, role := range exp.Roles {
+				if filter.Includes(role.Tags) {
+					role.Bullets = maskBullets(filter, role.Bullets)
+					roles[roleIndex], kept = role, true
+				}
+			}
+			// The renderer leaves out an entry whose roles are all excluded.
+			if !kept {
+				continue
+			}
+			exp.Roles = roles
+		}
+		data.Experience[index] = exp
+	}
+
+	data.Education = make([]models.EducationEntry, len(req.Data.Education))
+	for index, edu := range req.Data.Education {
+		edu.Bullets = maskBullets(filter, edu.Bullets)
+		data.Education[index] = edu
+	}
+
+	data.Projects = make([]models.ProjectEntry, len(req.Data.Projects))
+	for index, project := range req.Data.Projects {
+		if filter.Includes(project.Tags) {
+			project.Bullets = maskBullets(filter, project.Bullets)
+			data.Projects[index] = project
+		}
+	}
+
+	data.CustomSections = make([]models.CustomSection, len(req.Data.CustomSections))
+	for sectionIndex, custom := range req.Data.CustomSections {
+		entries := make([]models.CustomEntry, len(custom.Entries))
+		for entryIndex, entry := range custom.Entries {
+			entry.Bullets = maskBullets(filter, entry.Bullets)
+			entries[entryIndex] = entry
+		}
+		custom.Entries = entries
+		data.CustomSections[sectionIndex] = custom
+	}
+
+	req.Data = data
+	return req
+}
+
+// requestTags returns the normalized tags set anywhere in req.
+func requestTags(req models.GeneratePDFRequest) map[string]bool {
+	used := make(map[string]bool)
+	addTags := func(tags []string) {
+		for _, tag := range tags {
+			used[variant.NormalizeTag(tag)] = true
+		}
+	}
+	for _, exp := range req.Data.Experience {
+		addTags(exp.Tags)
+		for _, role := range exp.Roles {
+			addTags(role.Tags)
+		}
+	}
+	for _, project := range req.Data.Projects {
+		addTags(project.Tags)
+	}
+	for _, bullet := range resumeBullets(req.Data) {
+		addTags(bullet.tags)
+	}
+	return used
+}
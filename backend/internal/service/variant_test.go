@@ -0,0 +1,83 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestRequestWarningsCoverOnlyVariantContent(t *testing.T) {
+	req := validRequest()
+	req.Data.Experience = []models.ExperienceEntry{
+		{
+			Company: "Analytical Engines Ltd",
+			Role:    "Engineer",
+			Bullets: []models.Bullet{
+				{Text: "Built the card reader service"},
+				{Text: strings.Repeat("Trained the Bernoulli model on worked examples ", 5) + ".", Tags: []string{"ml"}},
+				{Text: "Tuned the [mill](engines.example.com)", Tags: []string{"backend"}},
+			},
+		},
+		{Company: "Difference Ltd", Role: "Researcher", URL: "difference.example.com", Tags: []string{"ml"}},
+	}
+	req.Variant = &models.ResumeVariant{Name: "Backend", IncludeTags: []string{"backend"}}
+
+	// The dropped overlong bullet and the dropped entry's link are not reported, and the
+	// kept bullets keep their request indexes.
+	want := []string{"linkWithoutScheme data.experience[0].bullets[2]"}
+	if got := warningKeys(requestWarnings(req, testNow)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v, got %v", want, got)
+	}
+}
+
+func TestVariantWarningsReportsUnmatchedTags(t *testing.T) {
+	req := validRequest()
+	req.Data.Experience = []models.ExperienceEntry{{
+		Company: "Analytical Engines Ltd",
+		Roles:   []models.ExperienceRole{{Role: "Engineer", Bullets: []models.Bullet{{Text: "Built the card reader.", Tags: []string{"backend"}}}}},
+	}}
+	req.Variant = &models.ResumeVariant{Name: "Backend", IncludeTags: []string{" Backend", "platform"}}
+
+	want := []string{"unmatchedVariantTag variant.includeTags[1]"}
+	if got := warningKeys(variantWarnings(req)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v, got %v", want, got)
+	}
+}
+
+func TestApplyVariantBlanksExcludedItems(t *testing.T) {
+	req := validRequest()
+	req.Data.Experience = []models.ExperienceEntry{
+		{Company: "Engines Ltd", Roles: []models.ExperienceRole{{Role: "Analyst", Tags: []string{"ml"}}}},
+		{
+			Company: "Difference Ltd",
+			Roles: []models.ExperienceRole{
+				{Role: "Researcher", Tags: []string{"ml"}},
+				{Role: "Engineer", Bullets: []models.Bullet{{Text: "Tuned the mill."}, {Text: "Trained the model.", Tags: []string{"ml"}}}},
+			},
+		},
+	}
+	req.Data.Projects = []models.ProjectEntry{{Name: "Bernoulli Numbers", Tags: []string{"ml"}}, {Name: "Card Reader"}}
+	req.Variant = &models.ResumeVariant{IncludeTags: []string{"backend"}}
+
+	shown := applyVariant(req)
+
+	// An entry whose roles are all excluded is blanked like an excluded entry.
+	wantExperience := []models.ExperienceEntry{
+		{},
+		{
+			Company: "Difference Ltd",
+			Roles: []models.ExperienceRole{
+				{},
+				{Role: "Engineer", Bullets: []models.Bullet{{Text: "Tuned the mill."}, {}}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(shown.Data.Experience, wantExperience) {
+		t.Fatalf("expected experience %+v, got %+v", wantExperience, shown.Data.Experience)
+	}
+	if wantProjects := []models.ProjectEntry{{}, {Name: "Card Reader"}}; !reflect.DeepEqual(shown.Data.Projects, wantProjects) {
+		t.Fatalf("expected projects %+v, got %+v", wantProjects, shown.Data.Projects)
+	}
+}
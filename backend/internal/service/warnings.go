@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"resume_maker/backend/internal/models"
+	"resume_maker/backend/internal/richtext"
+)
+
+// maxBulletLength is the longest bullet, in characters of plain text, that is not
+// reported as hard to scan.
+const maxBulletLength = 200
+
+// bulletRef is one bullet of the request with its field path.
+type bulletRef struct {
+	field string
+	text  string
+	tags  []string
+}
+
+// requestWarnings returns every non-blocking issue in a request that passed validate:
+// content warnings first, then variant and timeline warnings. Content and timeline
+// warnings only cover what renders: the variant's entries and bullets in the sections
+// settings.sectionVisibility leaves visible.
+func requestWarnings(req models.GeneratePDFRequest, now time.Time) []models.ValidationWarning {
+	shown := withoutHiddenSections(applyVariant(req))
+	warnings := contentWarnings(shown)
+	warnings = append(warnings, variantWarnings(req)...)
+	return append(warnings, timelineWarnings(shown, now)...)
+}
+
+// withoutHiddenSections returns req with the sections that settings.sectionVisibility
+// hides emptied. The entries of visible sections keep their indexes.
+func withoutHiddenSections(req models.GeneratePDFRequest) models.GeneratePDFRequest {
+	hidden := func(section string) bool {
+		visible, ok := req.Settings.SectionVisibility[section]
+		return ok && !visible
+	}
+	if hidden(models.SectionExperience) {
+		req.Data.Experience = nil
+	}
+	if hidden(models.SectionEducation) {
+		req.Data.Education = nil
+	}
+	if hidden(models.SectionProjects) {
+		req.Data.Projects = nil
+	}
+	if hidden(models.SectionTechnicalSkills) {
+		req.Data.TechnicalSkills = nil
+	}
+	if hidden(models.SectionCustom) {
+		req.Data.CustomSections = nil
+	}
+	return req
+}
+
+// contentWarnings reports a missing email, links without a scheme, overlong bullets, and
+// bullets whose trailing period differs from the rest of the resume.
+func contentWarnings(req models.GeneratePDFRequest) []models.ValidationWarning {
+	var warnings []models.ValidationWarning
+	info := req.Data.PersonalInfo
+	if strings.TrimSpace(info.Email) == "" {
+		warnings = append(warnings, models.ValidationWarning{
+			Field:   "data.personalInfo.email",
+			Code:    models.WarningMissingEmail,
+			Message: "is empty; most recruiters expect an email address",
+		})
+	}
+
+	type fieldLink struct{ field, url string }
+	links := []fieldLink{
+		{"data.personalInfo.linkedin", info.LinkedIn},
+		{"data.personalInfo.github", info.GitHub},
+		{"data.personalInfo.website", info.Website},
+	}
+	for index, link := range info.OtherLinks {
+		links = append(links, fieldLink{fmt.Sprintf("data.personalInfo.otherLinks[%d].url", index), link.URL})
+	}
+	for index, exp := range req.Data.Experience {
+		links = append(links, fieldLink{fmt.Sprintf("data.experience[%d].url", index), exp.URL})
+	}
+	for index, project := range req.Data.Projects {
+		links = append(links,
+			fieldLink{fmt.Sprintf("data.projects[%d].url", index), project.URL},
+			fieldLink{fmt.Sprintf("data.projects[%d].repoUrl", index), project.RepoURL},
+		)
+	}
+	for _, link := range links {
+		warnings = append(warnings, linkSchemeWarning(link.field, link.url)...)
+	}
+
+	bullets := resumeBullets(req.Data)
+	for _, bullet := range bullets {
+		if length := utf8.RuneCountInString(strings.TrimSpace(richtext.Plain(bullet.text))); length > maxBulletLength {
+			warnings = append(warnings, models.ValidationWarning{
+				Field:   bullet.field,
+				Code:    models.WarningLongBullet,
+				Message: fmt.Sprintf("is %d characters; bullets over %d characters are hard to scan", length, maxBulletLength),
+			})
+		}
+		spans, err := richtext.Parse(bullet.text)
+		if err != nil {
+			continue
+		}
+		for _, span := range spans {
+			if span.URL != "" {
+				warnings = append(warnings, linkSchemeWarning(bullet.field, span.URL)...)
+			}
+		}
+	}
+	return append(warnings, periodWarnings(bullets)...)
+}
+
+// linkSchemeWarning reports a link that will be opened with an assumed scheme.
+func linkSchemeWarning(field string, url string) []models.ValidationWarning {
+	trimmed := strings.TrimSpace(url)
+	if trimmed == "" || hasLinkScheme(trimmed) {
+		return nil
+	}
+	scheme := "https://"
+	if strings.Contains(trimmed, "@") && !strings.Contains(trimmed, "/") {
+		scheme = "mailto:"
+	}
+	return []models.ValidationWarning{{
+		Field:   field,
+		Code:    models.WarningLinkWithoutScheme,
+		Message: fmt.Sprintf("link %q has no scheme; %s will be assumed", trimmed, scheme),
+	}}
+}
+
+// hasLinkScheme mirrors the schemes the renderer keeps as written.
+func hasLinkScheme(url string) bool {
+	lower := strings.ToLower(url)
+	for _, prefix := range []string{"http://", "https://", "mailto:", "tel:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// periodWarnings reports bullets that disagree with the resume's usual trailing
+// punctuation: the convention of most bullets, or of the first bullet on a tie. Bullets
+// ending in other punctuation follow neither convention and are not counted.
+func periodWarnings(bullets []bulletRef) []models.ValidationWarning {
+	var withPeriod, withoutPeriod []bulletRef
+	firstHasPeriod := false
+	for _, bullet := range bullets {
+		text := strings.TrimSpace(richtext.Plain(bullet.text))
+		last, _ := utf8.DecodeLastRuneInString(text)
+		switch {
+		case text == "" || strings.ContainsRune("!?:;…", last):
+			continue
+		case last == '.':
+			withPeriod = append(withPeriod, bullet)
+			firstHasPeriod = firstHasPeriod || len(withoutPeriod) == 0
+		default:
+			withoutPeriod = append(withoutPeriod, bullet)
+		}
+	}
+	if len(withPeriod) == 0 || len(withoutPeriod) == 0 {
+		return nil
+	}
+
+	outliers, message := withPeriod, "ends with a period unlike most bullets"
+	if len(withPeriod) > len(withoutPeriod) || (len(withPeriod) == len(withoutPeriod) && firstHasPeriod) {
+		outliers, message = withoutPeriod, "has no trailing period unlike most bullets"
+	}
+
+	warnings := make([]models.ValidationWarning, 0, len(outliers))
+	for _, bullet := range outliers {
+		warnings = append(warnings, models.ValidationWarning{
+			Field:   bullet.field,
+			Code:    models.WarningInconsistentPeriods,
+			Message: message,
+		})
+	}
+	return warnings
+}
+
+// resumeBullets lists every bullet and sub-bullet of data in request order.
+func resumeBullets(data models.ResumeData) []bulletRef {
+	var refs []bulletRef
+	for index, exp := range data.Experience {
+		refs = appendBulletRefs(refs, fmt.Sprintf("data.experience[%d].bullets", index), exp.Bullets)
+		for roleIndex, role := range exp.Roles {
+			refs = appendBulletRefs(refs, fmt.Sprintf("data.experience[%d].roles[%d].bullets", index, roleIndex), role.Bullets)
+		}
+	}
+	for index, edu := range data.Education {
+		refs = appendBulletRefs(refs, fmt.Sprintf("data.education[%d].bullets", index), edu.Bullets)
+	}
+	for index, project := range data.Projects {
+		refs = appendBulletRefs(refs, fmt.Sprintf("data.projects[%d].bullets", index), project.Bullets)
+	}
+	for sectionIndex, custom := range data.CustomSections {
+		for entryIndex, entry := range custom.Entries {
+			refs = appendBulletRefs(refs, fmt.Sprintf("data.customSections[%d].entries[%d].bullets", sectionIndex, entryIndex), entry.Bullets)
+		}
+	}
+	return refs
+}
+
+func appendBulletRefs(refs []bulletRef, field string, bullets []models.Bullet) []bulletRef {
+	for index, bullet := range bullets {
+		bulletField := fmt.Sprintf("%s[%d]", field, index)
+		refs = append(refs, bulletRef{field: bulletField, text: bullet.Text, tags: bullet.Tags})
+		refs = appendBulletRefs(refs, bulletField+".children", bullet.Children)
+	}
+	return refs
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestContentWarningsReportsLinksBulletsAndMissingEmail(t *testing.T) {
+	req := validRequest()
+	req.Data.PersonalInfo = models.PersonalInfo{
+		FirstName: "Ada",
+		LastName:  "Lovelace",
+		LinkedIn:  "linkedin.com/in/ada",
+		Website:   "https://ada.example",
+	}
+	req.Data.Experience = []models.ExperienceEntry{{
+		Role:    "Analyst",
+		Company: "Babbage & Co",
+		Bullets: []models.Bullet{
+			{Text: "Wrote the first published program."},
+			{Text: "Translated Menabrea's memoir on the [Analytical Engine](fourmilab.ch/babbage)."},
+			{Text: "Annotated the translation", Children: []models.Bullet{{Text: strings.Repeat("Extended the notes with worked examples ", 6) + "."}}},
+		},
+	}}
+	req.Data.Projects = []models.ProjectEntry{{Name: "Notes", URL: "https://ada.example/notes", RepoURL: "github.com/ada/notes"}}
+
+	want := []string{
+		"missingEmail data.personalInfo.email",
+		"linkWithoutScheme data.personalInfo.linkedin",
+		"linkWithoutScheme data.projects[0].repoUrl",
+		"linkWithoutScheme data.experience[0].bullets[1]",
+		"longBullet data.experience[0].bullets[2].children[0]",
+		"inconsistentPeriods data.experience[0].bullets[2]",
+	}
+	if got := warningKeys(contentWarnings(req)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v, got %v", want, got)
+	}
+}
+
+func TestRequestWarningsSkipHiddenSections(t *testing.T) {
+	req := validRequest()
+	req.Data.Experience = []models.ExperienceEntry{{
+		Role:      "Analyst",
+		Company:   "Babbage & Co",
+		StartDate: "Jan 2015",
+		EndDate:   "Present",
+		Bullets:   []models.Bullet{{Text: "Wrote the first published program."}},
+	}}
+	req.Data.Projects = []models.ProjectEntry{{
+		Name:      "Notes",
+		URL:       "notes.example.com",
+		StartDate: "Sep 2099",
+		Bullets:   []models.Bullet{{Text: strings.Repeat("Extended the notes with worked examples ", 6) + "."}},
+	}}
+	req.Settings.SectionVisibility = map[string]bool{models.SectionProjects: false}
+
+	if got := requestWarnings(req, testNow); len(got) != 0 {
+		t.Fatalf("expected no warnings for the hidden projects section, got %v", warningKeys(got))
+	}
+
+	req.Settings.SectionVisibility = nil
+	want := []string{
+		"linkWithoutScheme data.projects[0].url",
+		"longBullet data.projects[0].bullets[0]",
+		"futureStartDate data.projects[0].startDate",
+	}
+	if got := warningKeys(requestWarnings(req, testNow)); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expected warnings %v once projects show, got %v", want, got)
+	}
+}
@@ -0,0 +1,93 @@
+// Package variant selects the entries, roles and bullets of a resume variant. The
+// renderer and the warnings computed on what it renders share these rules.
+package variant
+
+import (
+	"strings"
+
+	"resume_maker/backend/internal/models"
+)
+
+// Filter selects the entries and bullets of a resume variant. The zero value, used
+// when the request has no variant, includes everything.
+type Filter struct {
+	tags map[string]bool
+}
+
+// NewFilter returns the filter for variant, which may be nil.
+func NewFilter(variant *models.ResumeVariant) Filter {
+	if variant == nil {
+		return Filter{}
+	}
+	tags := make(map[string]bool, len(variant.IncludeTags))
+	for _, tag := range variant.IncludeTags {
+		if key := NormalizeTag(tag); key != "" {
+			tags[key] = true
+		}
+	}
+	return Filter{tags: tags}
+}
+
+// Active reports whether f leaves anything out.
+func (f Filter) Active() bool {
+	return f.tags != nil
+}
+
+// Includes reports whether an item with tags belongs to the variant: untagged items
+// always do, tagged ones when any of their tags is included.
+func (f Filter) Includes(tags []string) bool {
+	if f.tags == nil {
+		return true
+	}
+	tagged := false
+	for _, tag := range tags {
+		key := NormalizeTag(tag)
+		if key == "" {
+			continue
+		}
+		if f.tags[key] {
+			return true
+		}
+		tagged = true
+	}
+	return !tagged
+}
+
+// Bullets returns the bullets the variant includes. An excluded bullet takes its
+// sub-bullets with it.
+func (f Filter) Bullets(bullets []models.Bullet) []models.Bullet {
+	if f.tags == nil {
+		return bullets
+	}
+	var kept []models.Bullet
+	for _, bullet := range bullets {
+		if !f.Includes(bullet.Tags) {
+			continue
+		}
+		bullet.Children = f.Bullets(bullet.Children)
+		kept = append(kept, bullet)
+	}
+	return kept
+}
+
+// Roles returns the roles of a grouped experience entry the variant includes, with
+// their bullets filtered.
+func (f Filter) Roles(roles []models.ExperienceRole) []models.ExperienceRole {
+	if f.tags == nil {
+		return roles
+	}
+	kept := make([]models.ExperienceRole, 0, len(roles))
+	for _, role := range roles {
+		if !f.Includes(role.Tags) {
+			continue
+		}
+		role.Bullets = f.Bullets(role.Bullets)
+		kept = append(kept, role)
+	}
+	return kept
+}
+
+// NormalizeTag returns the form tags are matched in: trimmed and lower case.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
@@ -0,0 +1,48 @@
+package variant
+
+import (
+	"reflect"
+	"testing"
+
+	"resume_maker/backend/internal/models"
+)
+
+func TestFilterKeepsUntaggedAndMatchingBullets(t *testing.T) {
+	bullets := []models.Bullet{
+		{Text: "Shared"},
+		{Text: "Backend", Tags: []string{"backend"}, Children: []models.Bullet{
+			{Text: "Backend detail"},
+			{Text: "ML detail", Tags: []string{"ml"}},
+		}},
+		{Text: "ML", Tags: []string{"ML"}, Children: []models.Bullet{{Text: "Dropped with its parent"}}},
+		{Text: "Both", Tags: []string{"ml", " Backend "}},
+	}
+
+	filter := NewFilter(&models.ResumeVariant{IncludeTags: []string{"BACKEND"}})
+	want := []models.Bullet{
+		{Text: "Shared"},
+		{Text: "Backend", Tags: []string{"backend"}, Children: []models.Bullet{{Text: "Backend detail"}}},
+		{Text: "Both", Tags: []string{"ml", " Backend "}},
+	}
+	if got := filter.Bullets(bullets); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	if got := NewFilter(nil).Bullets(bullets); !reflect.DeepEqual(got, bullets) {
+		t.Fatalf("expected no variant to keep every bullet, got %+v", got)
+	}
+}
+
+func TestFilterRolesDropsExcludedRoles(t *testing.T) {
+	roles := []models.ExperienceRole{
+		{Role: "Model Builder", Tags: []string{"ml"}},
+		{Role: "Platform Lead", Tags: []string{"backend"}, Bullets: []models.Bullet{{Text: "Tuned", Tags: []string{"ml"}}}},
+		{Role: "Analyst"},
+	}
+
+	got := NewFilter(&models.ResumeVariant{IncludeTags: []string{"backend"}}).Roles(roles)
+	want := []models.ExperienceRole{{Role: "Platform Lead", Tags: []string{"backend"}}, {Role: "Analyst"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
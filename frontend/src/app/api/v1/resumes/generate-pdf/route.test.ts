@@ -52,6 +52,39 @@ describe('POST /api/v1/resumes/generate-pdf', () => {
     expect(await response.text()).toBe('pdf');
   });
 
+  it('forwards resume report headers', async () => {
+    const warnings = '[{"field":"data.personalInfo.email","code":"missingEmail"}]';
+    vi.mocked(proxyPDFRequest).mockResolvedValueOnce(
+      new Response('pdf', {
+        status: 200,
+        headers: {
+          'Content-Type': 'application/pdf',
+          'X-Resume-Pages': '2',
+          'X-Resume-Fit': '{"fontSize":10}',
+          'X-Resume-Warnings': warnings,
+          'X-Resume-Warning-Count': '1',
+          'X-Internal-Debug': 'hidden',
+        },
+      }),
+    );
+
+    const request = new Request('http://localhost:3000/api/v1/resumes/generate-pdf', {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: '{"data":{},"settings":{"showPhoto":false,"fontSize":"medium","fontFamily":"times"}}',
+    });
+
+    const response = await POST(request);
+
+    expect(response.headers.get('X-Resume-Pages')).toBe('2');
+    expect(response.headers.get('X-Resume-Fit')).toBe('{"fontSize":10}');
+    expect(response.headers.get('X-Resume-Warnings')).toBe(warnings);
+    expect(response.headers.get('X-Resume-Warning-Count')).toBe('1');
+    expect(response.headers.get('X-Internal-Debug')).toBeNull();
+  });
+
   it('returns BAD_GATEWAY when upstream call fails', async () => {
     vi.mocked(proxyPDFRequest).mockRejectedValueOnce(new Error('upstream down'));
 
@@ -1,6 +1,15 @@
 import { proxyPDFRequest } from '@/server/pdf-proxy';
 import { jsonError } from '@/server/json-error';
 
+const FORWARDED_HEADERS = [
+  'Content-Type',
+  'Content-Disposition',
+  'X-Resume-Pages',
+  'X-Resume-Fit',
+  'X-Resume-Warnings',
+  'X-Resume-Warning-Count',
+];
+
 export async function POST(request: Request): Promise<Response> {
   const contentType = request.headers.get('content-type') ?? '';
   if (!contentType.toLowerCase().includes('application/json')) {
@@ -16,14 +25,11 @@ export async function POST(request: Request): Promise<Response> {
     });
 
     const responseHeaders = new Headers();
-    const upstreamContentType = upstream.headers.get('Content-Type');
-    const upstreamDisposition = upstream.headers.get('Content-Disposition');
-
-    if (upstreamContentType) {
-      responseHeaders.set('Content-Type', upstreamContentType);
-    }
-    if (upstreamDisposition) {
-      responseHeaders.set('Content-Disposition', upstreamDisposition);
+    for (const name of FORWARDED_HEADERS) {
+      const value = upstream.headers.get(name);
+      if (value) {
+        responseHeaders.set(name, value);
+      }
     }
 
     return new Response(upstream.body, {